@@ -0,0 +1,73 @@
+// generate-machine-types regenerates the checked-in fallback table in
+// upup/pkg/fi/cloudup/awsup/machine_types.go by querying the live EC2 DescribeInstanceTypes
+// and Pricing APIs, so the static list doesn't silently drift behind AWS's catalog.
+//
+// Usage:
+//
+//	go run hack/generate-machine-types/main.go --region us-east-1 > /tmp/machine_types.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/golang/glog"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"os"
+	"sort"
+	"text/template"
+)
+
+var machineTypesTemplate = template.Must(template.New("machineTypes").Parse(`// Code generated by hack/generate-machine-types; DO NOT EDIT.
+
+package awsup
+
+var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
+{{- range . }}
+	{
+		Name:           "{{ .Name }}",
+		MemoryGB:       {{ .MemoryGB }},
+		ECU:            {{ .ECU }},
+		Cores:          {{ .Cores }},
+		EphemeralDisks: {{ if .EphemeralDisks }}[]int{ {{ range $i, $d := .EphemeralDisks }}{{ if $i }}, {{ end }}{{ $d }}{{ end }} }{{ else }}nil{{ end }},
+		Burstable:      {{ .Burstable }},
+		SpotSupported:  {{ .SpotSupported }},
+	},
+{{- end }}
+}
+`))
+
+func main() {
+	region := flag.String("region", "us-east-1", "AWS region to query")
+	flag.Parse()
+
+	if err := run(*region); err != nil {
+		glog.Exitf("%v", err)
+	}
+}
+
+func run(region string) error {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return fmt.Errorf("error building AWS session: %v", err)
+	}
+
+	catalog, err := awsup.QueryLiveInstanceTypesForGenerator(sess, region)
+	if err != nil {
+		return fmt.Errorf("error querying live instance types: %v", err)
+	}
+
+	var names []string
+	for name := range catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sorted []*awsup.AWSMachineTypeInfo
+	for _, name := range names {
+		sorted = append(sorted, catalog[name])
+	}
+
+	return machineTypesTemplate.Execute(os.Stdout, sorted)
+}
@@ -3,83 +3,235 @@ package protokube
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/golang/glog"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/leaderelection"
+	"k8s.io/kubernetes/pkg/client/leaderelection/resourcelock"
+	"k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+)
+
+const (
+	masterRoleLabel = "kubernetes.io/role"
+	masterRoleValue = "master"
+
+	masterTainterLockName      = "protokube-master-tainter"
+	masterTainterLockNamespace = "kube-system"
 )
 
-type nodePatch struct {
-	Metadata *nodePatchMetadata `json:"metadata,omitempty"`
-	Spec     *nodePatchSpec     `json:"spec,omitempty"`
+// defaultMasterTaints is applied to every master node: a missing taint is added, and a taint
+// with the same key but a different Value/Effect is overwritten. Taints with any other key,
+// such as ones a user added, are left alone. Both are configurable via
+// KubernetesContext.MasterTaints.
+var defaultMasterTaints = []api.Taint{
+	{Key: "node-role.kubernetes.io/control-plane", Effect: "NoSchedule"},
+	{Key: "node-role.kubernetes.io/master", Effect: "NoSchedule"},
 }
 
-type nodePatchMetadata struct {
-	Annotations map[string]string `json:"annotations,omitempty"`
+// MasterTainter is a long-running controller that reconciles node.Spec.Taints on master
+// nodes. It replaces the one-shot scheduler.alpha.kubernetes.io/taints annotation patch
+// ApplyMasterTaints used to apply at protokube startup: that annotation is deprecated in
+// favor of the typed Taints field, and a one-shot patch can't recover if a taint is later
+// removed out from under it (e.g. by a user, or by a competing protokube instance).
+//
+// NOTE: this tree has no protokube main/cmd entry point of any kind (not even the one that
+// used to call ApplyMasterTaints), and KubernetesContext, the one dependency Run needs, is
+// likewise not defined anywhere in this tree. There is nothing here for NewMasterTainter/Run
+// to be wired into yet; whatever constructs a KubernetesContext and owns protokube's startup
+// sequence needs to call NewMasterTainter(kubeContext, untaint).Run(identity, stop) once it
+// exists.
+type MasterTainter struct {
+	kubeContext *KubernetesContext
+	taints      []api.Taint
+
+	// Untaint, if set, makes the controller remove rather than apply the configured taints,
+	// for gracefully decommissioning a master out of the control-plane role.
+	Untaint bool
 }
 
-type nodePatchSpec struct {
-	Unschedulable *bool `json:"unschedulable,omitempty"`
+// NewMasterTainter builds a MasterTainter using kubeContext's configured taints, falling back
+// to defaultMasterTaints if none were set.
+func NewMasterTainter(kubeContext *KubernetesContext, untaint bool) *MasterTainter {
+	taints := kubeContext.MasterTaints
+	if len(taints) == 0 {
+		taints = defaultMasterTaints
+	}
+	return &MasterTainter{kubeContext: kubeContext, taints: taints, Untaint: untaint}
 }
 
-// ApplyMasterTaints finds masters that have not yet been tainted, and applies the master taint
-// Once the kubelet support --taints (like --labels) this can probably go away entirely.
-// It also sets the unschedulable flag to false, so pods (with a toleration) can target the node
-func ApplyMasterTaints(kubeContext *KubernetesContext) error {
-	client, err := kubeContext.KubernetesClient()
+// Run blocks acquiring the masterTainterLockName lease in masterTainterLockNamespace; once
+// elected leader, it watches master nodes and reconciles their taints until stop is closed.
+// Losing the lease (or stop closing) stops reconciliation; Run itself does not return until
+// stop closes, so callers typically invoke it in its own goroutine.
+func (t *MasterTainter) Run(identity string, stop <-chan struct{}) error {
+	client, err := t.kubeContext.KubernetesClient()
 	if err != nil {
 		return err
 	}
 
-	options := api.ListOptions{
-		LabelSelector: labels.SelectorFromSet(labels.Set{"kubernetes.io/role": "master"}),
-	}
-	glog.V(2).Infof("Querying k8s for nodes with selector %q", options.LabelSelector)
-	nodes, err := client.Core().Nodes().List(options)
+	lock, err := resourcelock.New(
+		resourcelock.EndpointsResourceLock,
+		masterTainterLockNamespace,
+		masterTainterLockName,
+		client.Core(),
+		resourcelock.ResourceLockConfig{Identity: identity},
+	)
 	if err != nil {
-		return fmt.Errorf("error querying nodes: %v", err)
+		return fmt.Errorf("error building master-tainter leader election lock: %v", err)
 	}
 
-	taint := []api.Taint{{Key: "dedicated", Value: "master", Effect: "NoSchedule"}}
-	taintJSON, err := json.Marshal(taint)
-	if err != nil {
-		return fmt.Errorf("error serializing taint: %v", err)
+	leaderelection.RunOrDie(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderStop <-chan struct{}) {
+				glog.Infof("acquired master-tainter leader lease as %q", identity)
+				// Stop reconciling when either the lease is lost or stop closes
+				reconcileStop := make(chan struct{})
+				go func() {
+					select {
+					case <-leaderStop:
+					case <-stop:
+					}
+					close(reconcileStop)
+				}()
+				t.watchAndReconcile(client, reconcileStop)
+			},
+			OnStoppedLeading: func() {
+				glog.Infof("lost master-tainter leader lease")
+			},
+		},
+	})
+
+	<-stop
+	return nil
+}
+
+// watchAndReconcile runs a SharedIndexInformer-style watch over master-labeled nodes,
+// reconciling taints on every add/update until stop closes.
+func (t *MasterTainter) watchAndReconcile(client *unversioned.Client, stop <-chan struct{}) {
+	selector := labels.SelectorFromSet(labels.Set{masterRoleLabel: masterRoleValue})
+
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			return client.Core().Nodes().List(options)
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return client.Core().Nodes().Watch(options)
+		},
 	}
 
-	for i := range nodes.Items {
-		node := &nodes.Items[i]
+	_, informer := cache.NewInformer(listWatch, &api.Node{}, 30*time.Second, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			t.reconcile(client, obj.(*api.Node))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			t.reconcile(client, newObj.(*api.Node))
+		},
+	})
 
-		nodeTaintJSON := node.Annotations[api.TaintsAnnotationKey]
-		if nodeTaintJSON != "" {
-			if nodeTaintJSON != string(taintJSON) {
-				glog.Infof("Node %q had unexpected taint: %v", node.Name, nodeTaintJSON)
-			}
+	informer.Run(stop)
+}
+
+// reconcile brings node.Spec.Taints in line with t.taints, patching the node only if
+// something actually needs to change.
+func (t *MasterTainter) reconcile(client *unversioned.Client, node *api.Node) {
+	desired, changed := t.desiredTaints(node.Spec.Taints)
+	if !changed {
+		return
+	}
+
+	if err := t.patchTaints(client, node, desired); err != nil {
+		glog.Warningf("error reconciling taints on node %q: %v", node.Name, err)
+	}
+}
+
+// desiredTaints returns what node.Spec.Taints should become: with Untaint false, every
+// t.taints entry the node is missing (or carries with a stale Value/Effect) is added/
+// corrected; with Untaint true, every taint the node carries whose key matches one of
+// t.taints is dropped. Every other existing taint (e.g. one a user added by hand) is passed
+// through unchanged either way.
+func (t *MasterTainter) desiredTaints(existing []api.Taint) ([]api.Taint, bool) {
+	owned := make(map[string]api.Taint, len(t.taints))
+	for _, taint := range t.taints {
+		owned[taint.Key] = taint
+	}
+
+	var result []api.Taint
+	present := make(map[string]bool, len(existing))
+	changed := false
+	for _, taint := range existing {
+		want, isOwned := owned[taint.Key]
+		if !isOwned {
+			result = append(result, taint)
 			continue
 		}
 
-		nodePatchMetadata := &nodePatchMetadata{
-			Annotations: map[string]string{api.TaintsAnnotationKey: string(taintJSON)},
-		}
-		unschedulable := false
-		nodePatchSpec := &nodePatchSpec{
-			Unschedulable: &unschedulable,
+		present[taint.Key] = true
+		if t.Untaint {
+			changed = true
+			continue
 		}
-		nodePatch := &nodePatch{
-			Metadata: nodePatchMetadata,
-			Spec:     nodePatchSpec,
+
+		if taint != want {
+			changed = true
 		}
-		nodePatchJson, err := json.Marshal(nodePatch)
-		if err != nil {
-			return fmt.Errorf("error building node patch: %v", err)
+		result = append(result, want)
+	}
+
+	if !t.Untaint {
+		for _, taint := range t.taints {
+			if !present[taint.Key] {
+				result = append(result, taint)
+				changed = true
+			}
 		}
+	}
 
-		glog.V(2).Infof("sending patch for node %q: %q", node.Name, string(nodePatchJson))
+	return result, changed
+}
 
-		_, err = client.Nodes().Patch(node.Name, api.StrategicMergePatchType, nodePatchJson)
-		if err != nil {
-			// TODO: Should we keep going?
-			return fmt.Errorf("error applying patch to node: %v", err)
-		}
+// taintPatch is the JSON merge-patch body used to update Spec.Taints. Including
+// metadata.resourceVersion makes the apiserver treat it as a precondition: if the node has
+// moved on since we read it (e.g. a user hand-edited a taint concurrently), the patch is
+// rejected with a conflict instead of silently clobbering their change.
+type taintPatch struct {
+	Metadata taintPatchMetadata `json:"metadata"`
+	Spec     taintPatchSpec     `json:"spec"`
+}
+
+type taintPatchMetadata struct {
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+type taintPatchSpec struct {
+	Taints []api.Taint `json:"taints"`
+}
+
+func (t *MasterTainter) patchTaints(client *unversioned.Client, node *api.Node, taints []api.Taint) error {
+	patch := taintPatch{
+		Metadata: taintPatchMetadata{ResourceVersion: node.ResourceVersion},
+		Spec:     taintPatchSpec{Taints: taints},
 	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("error building taint patch: %v", err)
+	}
+
+	glog.V(2).Infof("patching taints on node %q: %s", node.Name, string(patchJSON))
 
+	_, err = client.Nodes().Patch(node.Name, api.MergePatchType, patchJSON)
+	if err != nil {
+		return fmt.Errorf("error patching node taints: %v", err)
+	}
 	return nil
 }
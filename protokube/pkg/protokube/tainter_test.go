@@ -0,0 +1,75 @@
+package protokube
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+)
+
+func TestMasterTainter_DesiredTaints(t *testing.T) {
+	owned := []api.Taint{
+		{Key: "node-role.kubernetes.io/master", Effect: "NoSchedule"},
+	}
+
+	cases := []struct {
+		name        string
+		untaint     bool
+		existing    []api.Taint
+		wantResult  []api.Taint
+		wantChanged bool
+	}{
+		{
+			name:        "adds a missing owned taint",
+			existing:    nil,
+			wantResult:  owned,
+			wantChanged: true,
+		},
+		{
+			name:        "leaves an already-correct owned taint alone",
+			existing:    owned,
+			wantResult:  owned,
+			wantChanged: false,
+		},
+		{
+			name:        "corrects a drifted owned taint's effect",
+			existing:    []api.Taint{{Key: "node-role.kubernetes.io/master", Effect: "NoExecute"}},
+			wantResult:  owned,
+			wantChanged: true,
+		},
+		{
+			name:        "passes through an unrelated user-added taint",
+			existing:    []api.Taint{{Key: "example.com/custom", Effect: "NoSchedule"}},
+			wantResult:  append([]api.Taint{{Key: "example.com/custom", Effect: "NoSchedule"}}, owned...),
+			wantChanged: true,
+		},
+		{
+			name:        "untaint removes an owned taint",
+			untaint:     true,
+			existing:    owned,
+			wantResult:  nil,
+			wantChanged: true,
+		},
+		{
+			name:        "untaint is a no-op when the owned taint is already absent",
+			untaint:     true,
+			existing:    nil,
+			wantResult:  nil,
+			wantChanged: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tainter := &MasterTainter{taints: owned, Untaint: c.untaint}
+
+			result, changed := tainter.desiredTaints(c.existing)
+			if changed != c.wantChanged {
+				t.Fatalf("changed = %v, want %v", changed, c.wantChanged)
+			}
+			if !reflect.DeepEqual(result, c.wantResult) {
+				t.Fatalf("result = %v, want %v", result, c.wantResult)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package awsup
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/golang/glog"
+	"k8s.io/kops/upup/pkg/fi"
+	"time"
+)
+
+// AWSCloudUpgrader implements fi.CloudUpgrader against AWSCloud. It holds the behavior that
+// used to live directly inline in kutil.UpgradeCluster.Upgrade, extracted so that upgrade
+// orchestration can run against other clouds through the same fi.CloudUpgrader interface.
+type AWSCloudUpgrader struct {
+	Cloud *AWSCloud
+}
+
+var _ fi.CloudUpgrader = &AWSCloudUpgrader{}
+
+func NewAWSCloudUpgrader(cloud *AWSCloud) *AWSCloudUpgrader {
+	return &AWSCloudUpgrader{Cloud: cloud}
+}
+
+func (u *AWSCloudUpgrader) FindInstancesByClusterTag(clusterName string) ([]*fi.CloudInstance, error) {
+	request := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + TagClusterName),
+				Values: aws.StringSlice([]string{clusterName}),
+			},
+		},
+	}
+
+	var cloudInstances []*fi.CloudInstance
+	response, err := u.Cloud.EC2.DescribeInstances(request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances: %v", err)
+	}
+	for _, reservation := range response.Reservations {
+		for _, instance := range reservation.Instances {
+			role, _ := FindEC2Tag(instance.Tags, "Role")
+
+			var volumeIDs []string
+			for _, bdm := range instance.BlockDeviceMappings {
+				if bdm.Ebs != nil && bdm.Ebs.VolumeId != nil {
+					volumeIDs = append(volumeIDs, aws.StringValue(bdm.Ebs.VolumeId))
+				}
+			}
+
+			cloudInstances = append(cloudInstances, &fi.CloudInstance{
+				ID:        aws.StringValue(instance.InstanceId),
+				Role:      role,
+				Status:    aws.StringValue(instance.State.Name),
+				VolumeIDs: volumeIDs,
+			})
+		}
+	}
+	return cloudInstances, nil
+}
+
+func (u *AWSCloudUpgrader) RetagResource(id string, tags map[string]string) error {
+	return u.Cloud.CreateTags(id, tags)
+}
+
+func (u *AWSCloudUpgrader) ScaleInstanceGroup(name string, desiredCapacity int64) error {
+	request := &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		DesiredCapacity:      aws.Int64(desiredCapacity),
+		MinSize:              aws.Int64(desiredCapacity),
+		MaxSize:              aws.Int64(desiredCapacity),
+	}
+
+	_, err := u.Cloud.Autoscaling.UpdateAutoScalingGroup(request)
+	if err != nil {
+		return fmt.Errorf("error updating autoscaling group %q: %v", name, err)
+	}
+	return nil
+}
+
+func (u *AWSCloudUpgrader) StopMasters(instanceIDs []string) error {
+	for _, instanceID := range instanceIDs {
+		glog.Infof("Stopping master: %q", instanceID)
+
+		request := &ec2.StopInstancesInput{
+			InstanceIds: aws.StringSlice([]string{instanceID}),
+		}
+
+		_, err := u.Cloud.EC2.StopInstances(request)
+		if err != nil {
+			return fmt.Errorf("error stopping master instance %q: %v", instanceID, err)
+		}
+	}
+	return nil
+}
+
+func (u *AWSCloudUpgrader) DetachMasterVolumes(instanceIDs []string) error {
+	for _, instanceID := range instanceIDs {
+		instances, err := u.Cloud.EC2.DescribeInstances(&ec2.DescribeInstancesInput{
+			InstanceIds: aws.StringSlice([]string{instanceID}),
+		})
+		if err != nil {
+			return fmt.Errorf("error describing instance %q: %v", instanceID, err)
+		}
+
+		for _, reservation := range instances.Reservations {
+			for _, instance := range reservation.Instances {
+				for _, bdm := range instance.BlockDeviceMappings {
+					if bdm.Ebs == nil || bdm.Ebs.VolumeId == nil {
+						continue
+					}
+					volumeID := aws.StringValue(bdm.Ebs.VolumeId)
+					glog.Infof("Detaching volume %q from instance %q", volumeID, instanceID)
+
+					request := &ec2.DetachVolumeInput{
+						VolumeId:   bdm.Ebs.VolumeId,
+						InstanceId: aws.String(instanceID),
+					}
+
+					for {
+						_, err := u.Cloud.EC2.DetachVolume(request)
+						if err != nil {
+							if AWSErrorCode(err) == "IncorrectState" {
+								glog.Infof("retrying to detach volume (master has probably not stopped yet): %q", err)
+								time.Sleep(5 * time.Second)
+								continue
+							}
+							return fmt.Errorf("error detaching volume %q from master instance %q: %v", volumeID, instanceID, err)
+						}
+						break
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (u *AWSCloudUpgrader) AdoptLoadBalancers(clusterName string, newTags map[string]string) error {
+	var elbs []*elb.LoadBalancerDescription
+	request := &elb.DescribeLoadBalancersInput{}
+	err := u.Cloud.ELB.DescribeLoadBalancersPages(request, func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+		elbs = append(elbs, page.LoadBalancerDescriptions...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error listing ELBs: %v", err)
+	}
+
+	for _, lb := range elbs {
+		id := aws.StringValue(lb.LoadBalancerName)
+
+		tags, err := u.Cloud.GetELBTags(id)
+		if err != nil {
+			return fmt.Errorf("error getting tags for ELB %q: %v", id, err)
+		}
+		if tags[TagClusterName] != clusterName {
+			continue
+		}
+
+		glog.Infof("Retagging ELB %q", id)
+		if err := u.Cloud.CreateELBTags(id, newTags); err != nil {
+			return fmt.Errorf("error re-tagging ELB %q: %v", id, err)
+		}
+
+		for _, sg := range lb.SecurityGroups {
+			sgID := aws.StringValue(sg)
+
+			glog.Infof("Retagging ELB security group %q", sgID)
+			if err := u.Cloud.CreateTags(sgID, newTags); err != nil {
+				return fmt.Errorf("error re-tagging ELB security group %q: %v", sgID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package awsup
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/golang/glog"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+)
+
+// instanceTypeCacheTTL is how long a region's cached instance-type catalog is trusted before
+// we re-query the live EC2/Pricing APIs
+const instanceTypeCacheTTL = 7 * 24 * time.Hour
+
+// InstanceTypeCatalog resolves AWSMachineTypeInfo for an instance type name. It is consulted
+// by GetMachineTypeInfo instead of the static MachineTypes table directly, so that new
+// families (e.g. r4, m5, c5, p2, p3) are available as soon as AWS ships them, without
+// requiring a kops code change.
+type InstanceTypeCatalog struct {
+	Region string
+
+	// providers are tried in order; the first to return a non-nil result wins
+	providers []instanceTypeProvider
+}
+
+// instanceTypeProvider is a single source of AWSMachineTypeInfo lookups
+type instanceTypeProvider interface {
+	Lookup(name string) (*AWSMachineTypeInfo, error)
+}
+
+// NewInstanceTypeCatalog builds the default catalog: a live EC2/Pricing-backed provider
+// (cached to disk) falling back to the static MachineTypes table.
+func NewInstanceTypeCatalog(region string) *InstanceTypeCatalog {
+	return &InstanceTypeCatalog{
+		Region: region,
+		providers: []instanceTypeProvider{
+			newCachingLiveInstanceTypeProvider(region),
+			&staticInstanceTypeProvider{},
+		},
+	}
+}
+
+// Lookup resolves name against each provider in turn, returning the first match
+func (c *InstanceTypeCatalog) Lookup(name string) (*AWSMachineTypeInfo, error) {
+	var lastErr error
+	for _, p := range c.providers {
+		info, err := p.Lookup(name)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if info != nil {
+			return info, nil
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("instance type not handled: %q", name)
+}
+
+// staticInstanceTypeProvider is the hand-maintained fallback table
+type staticInstanceTypeProvider struct{}
+
+func (p *staticInstanceTypeProvider) Lookup(name string) (*AWSMachineTypeInfo, error) {
+	for i := range MachineTypes {
+		m := &MachineTypes[i]
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// cachingLiveInstanceTypeProvider queries EC2 DescribeInstanceTypes (and the Pricing API for
+// ECU; the on-demand price is not read) on first use, and caches the whole region's catalog to
+// ~/.kops/cache/instance-types-<region>.json for instanceTypeCacheTTL.
+type cachingLiveInstanceTypeProvider struct {
+	region   string
+	cacheDir string
+
+	loaded bool
+	cache  map[string]*AWSMachineTypeInfo
+}
+
+func newCachingLiveInstanceTypeProvider(region string) *cachingLiveInstanceTypeProvider {
+	cacheDir := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		cacheDir = path.Join(home, ".kops", "cache")
+	}
+	return &cachingLiveInstanceTypeProvider{region: region, cacheDir: cacheDir}
+}
+
+func (p *cachingLiveInstanceTypeProvider) cachePath() string {
+	if p.cacheDir == "" {
+		return ""
+	}
+	return path.Join(p.cacheDir, fmt.Sprintf("instance-types-%s.json", p.region))
+}
+
+func (p *cachingLiveInstanceTypeProvider) Lookup(name string) (*AWSMachineTypeInfo, error) {
+	if !p.loaded {
+		if err := p.load(); err != nil {
+			// A failure to reach EC2/Pricing (e.g. no credentials, no network) is not fatal:
+			// we just fall through to the static table.
+			glog.V(2).Infof("unable to query live instance type catalog for region %q: %v", p.region, err)
+			return nil, nil
+		}
+		p.loaded = true
+	}
+	return p.cache[name], nil
+}
+
+func (p *cachingLiveInstanceTypeProvider) load() error {
+	if cached, ok := p.readCache(); ok {
+		p.cache = cached
+		return nil
+	}
+
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(p.region)})
+	if err != nil {
+		return fmt.Errorf("error building AWS session: %v", err)
+	}
+
+	catalog, err := queryLiveInstanceTypes(sess, p.region)
+	if err != nil {
+		return err
+	}
+
+	p.cache = catalog
+	p.writeCache(catalog)
+	return nil
+}
+
+func (p *cachingLiveInstanceTypeProvider) readCache() (map[string]*AWSMachineTypeInfo, bool) {
+	cachePath := p.cachePath()
+	if cachePath == "" {
+		return nil, false
+	}
+
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	if time.Since(info.ModTime()) > instanceTypeCacheTTL {
+		return nil, false
+	}
+
+	b, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	var catalog map[string]*AWSMachineTypeInfo
+	if err := json.Unmarshal(b, &catalog); err != nil {
+		glog.Warningf("ignoring corrupt instance type cache %q: %v", cachePath, err)
+		return nil, false
+	}
+	return catalog, true
+}
+
+func (p *cachingLiveInstanceTypeProvider) writeCache(catalog map[string]*AWSMachineTypeInfo) {
+	cachePath := p.cachePath()
+	if cachePath == "" {
+		return
+	}
+
+	b, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		glog.Warningf("error marshalling instance type cache: %v", err)
+		return
+	}
+	if err := os.MkdirAll(p.cacheDir, 0755); err != nil {
+		glog.Warningf("error creating cache directory %q: %v", p.cacheDir, err)
+		return
+	}
+	if err := ioutil.WriteFile(cachePath, b, 0644); err != nil {
+		glog.Warningf("error writing instance type cache %q: %v", cachePath, err)
+	}
+}
+
+// QueryLiveInstanceTypesForGenerator is the entry point used by
+// hack/generate-machine-types to refresh the static fallback table; it is otherwise
+// equivalent to what cachingLiveInstanceTypeProvider does internally.
+func QueryLiveInstanceTypesForGenerator(sess *session.Session, region string) (map[string]*AWSMachineTypeInfo, error) {
+	return queryLiveInstanceTypes(sess, region)
+}
+
+// queryLiveInstanceTypes calls EC2 DescribeInstanceTypes for the hardware shape of every
+// instance type available in region, then cross-references the Pricing API for ECU. The
+// on-demand price is not read or stored. hack/generate-machine-types reaches it through
+// QueryLiveInstanceTypesForGenerator to refresh the static fallback table.
+func queryLiveInstanceTypes(sess *session.Session, region string) (map[string]*AWSMachineTypeInfo, error) {
+	ec2Client := ec2.New(sess)
+	pricingClient := pricing.New(sess, aws.NewConfig().WithRegion("us-east-1")) // Pricing API is only in us-east-1
+
+	catalog := make(map[string]*AWSMachineTypeInfo)
+
+	request := &ec2.DescribeInstanceTypesInput{}
+	err := ec2Client.DescribeInstanceTypesPages(request, func(page *ec2.DescribeInstanceTypesOutput, lastPage bool) bool {
+		for _, it := range page.InstanceTypes {
+			info := &AWSMachineTypeInfo{
+				Name:          aws.StringValue(it.InstanceType),
+				MemoryGB:      float32(aws.Int64Value(it.MemoryInfo.SizeInMiB)) / 1024,
+				Cores:         int(aws.Int64Value(it.VCpuInfo.DefaultVCpus)),
+				Burstable:     aws.BoolValue(it.BurstablePerformanceSupported),
+				SpotSupported: containsString(it.SupportedUsageClasses, "spot"),
+			}
+			if it.InstanceStorageInfo != nil {
+				for _, disk := range it.InstanceStorageInfo.Disks {
+					for i := int64(0); i < aws.Int64Value(disk.Count); i++ {
+						info.EphemeralDisks = append(info.EphemeralDisks, int(aws.Int64Value(disk.SizeInGB)))
+					}
+				}
+			}
+			catalog[info.Name] = info
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error calling EC2 DescribeInstanceTypes: %v", err)
+	}
+
+	for name, info := range catalog {
+		ecu, err := lookupECUFromPricing(pricingClient, region, name)
+		if err != nil {
+			glog.V(2).Infof("unable to determine ECU/price for %q: %v", name, err)
+			continue
+		}
+		info.ECU = ecu
+	}
+
+	return catalog, nil
+}
+
+func lookupECUFromPricing(pricingClient *pricing.Pricing, region, instanceType string) (float32, error) {
+	// The Pricing API models "ECU" as a product attribute string (often "NA" for burstable
+	// instance types); callers that need the on-demand price can extend this to read
+	// terms.OnDemand from the same GetProducts response.
+	output, err := pricingClient.GetProducts(&pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []*pricing.Filter{
+			{Type: aws.String("TERM_MATCH"), Field: aws.String("instanceType"), Value: aws.String(instanceType)},
+			{Type: aws.String("TERM_MATCH"), Field: aws.String("location"), Value: aws.String(regionToPricingLocation(region))},
+		},
+		MaxResults: aws.Int64(1),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(output.PriceList) == 0 {
+		return 0, fmt.Errorf("no pricing data found for %q in %q", instanceType, region)
+	}
+
+	// PriceList entries are opaque JSON documents; ECU lives under product.attributes.ecu
+	attrs, _ := output.PriceList[0]["product"].(map[string]interface{})
+	product, _ := attrs["attributes"].(map[string]interface{})
+	ecuStr, _ := product["ecu"].(string)
+
+	var ecu float32
+	if _, err := fmt.Sscanf(ecuStr, "%f", &ecu); err != nil {
+		return 0, fmt.Errorf("unparseable ECU value %q", ecuStr)
+	}
+	return ecu, nil
+}
+
+func containsString(values []*string, target string) bool {
+	for _, v := range values {
+		if aws.StringValue(v) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// regionToPricingLocation maps an EC2 region code to the "location" attribute the Pricing
+// API expects; this mirrors the table AWS documents for the Price List API.
+func regionToPricingLocation(region string) string {
+	locations := map[string]string{
+		"us-east-1": "US East (N. Virginia)",
+		"us-east-2": "US East (Ohio)",
+		"us-west-1": "US West (N. California)",
+		"us-west-2": "US West (Oregon)",
+		"eu-west-1": "EU (Ireland)",
+	}
+	if location, ok := locations[region]; ok {
+		return location
+	}
+	return region
+}
@@ -3,11 +3,23 @@ package awsup
 import (
 	"fmt"
 	"github.com/golang/glog"
+	"strings"
 )
 
 // I believe one vCPU ~ 3 ECUS, and 60 CPU credits would be needed to use one vCPU for an hour
 const BurstableCreditsToECUS float32 = 3.0 / 60.0
 
+// InstanceFamilyGeneration classifies an instance family by how recent it is.
+// SelectCompatibleInstanceTypes does not consult it: it only returns siblings from the base
+// type's own family, which share that family's generation.
+type InstanceFamilyGeneration int
+
+const (
+	GenerationUnknown InstanceFamilyGeneration = iota
+	GenerationCurrent
+	GenerationPrevious
+)
+
 type AWSMachineTypeInfo struct {
 	Name           string
 	MemoryGB       float32
@@ -15,6 +27,24 @@ type AWSMachineTypeInfo struct {
 	Cores          int
 	EphemeralDisks []int
 	Burstable      bool
+
+	// SpotSupported is false for the handful of families (e.g. t2) that AWS does not
+	// offer as spot instances
+	SpotSupported bool
+	// HibernationSupported is true for families that support EC2 hibernation on spot
+	// interruption, letting in-memory state survive a reclaim
+	HibernationSupported bool
+	// Generation classifies the instance family as current or previous-generation
+	Generation InstanceFamilyGeneration
+}
+
+// Family returns the instance family portion of the name, e.g. "m4" for "m4.xlarge"
+func (m *AWSMachineTypeInfo) Family() string {
+	family := m.Name
+	if dot := strings.Index(family, "."); dot != -1 {
+		family = family[:dot]
+	}
+	return family
 }
 
 type EphemeralDevice struct {
@@ -42,15 +72,53 @@ func (m *AWSMachineTypeInfo) EphemeralDevices() []*EphemeralDevice {
 	return disks
 }
 
-func GetMachineTypeInfo(machineType string) (*AWSMachineTypeInfo, error) {
+// defaultInstanceTypeCatalog is built lazily, once we know which region to query
+var defaultInstanceTypeCatalog *InstanceTypeCatalog
+
+// GetMachineTypeInfo looks up machineType, preferring a live, cached EC2/Pricing-backed
+// catalog for region over the static MachineTypes table, so that newly-released families
+// don't require a kops code change to recognize.
+func GetMachineTypeInfo(region, machineType string) (*AWSMachineTypeInfo, error) {
+	if defaultInstanceTypeCatalog == nil || defaultInstanceTypeCatalog.Region != region {
+		defaultInstanceTypeCatalog = NewInstanceTypeCatalog(region)
+	}
+	return defaultInstanceTypeCatalog.Lookup(machineType)
+}
+
+// SelectCompatibleInstanceTypes walks MachineTypes and returns the names of instance types
+// that could stand in for base in a spot/mixed-instances override list: siblings in the same
+// family with at least minCores vCPUs and minMemGB of memory, and a matching ephemeral/EBS-only
+// disk profile (so a launch template's block device mapping stays valid across every override).
+// region is passed through to GetMachineTypeInfo so base's info comes from the live catalog for
+// that region rather than the static table.
+//
+// No InstanceGroup spec field or ASG builder task exists anywhere in this tree to consume this
+// list yet (there is no awstasks package at all here), so nothing calls this function today.
+func SelectCompatibleInstanceTypes(region string, base string, minCores int, minMemGB float32) ([]string, error) {
+	baseInfo, err := GetMachineTypeInfo(region, base)
+	if err != nil {
+		return nil, err
+	}
+	baseEphemeral := len(baseInfo.EphemeralDisks) > 0
+
+	var compatible []string
 	for i := range MachineTypes {
 		m := &MachineTypes[i]
-		if m.Name == machineType {
-			return m, nil
+		if m.Name == base {
+			continue
 		}
+		if m.Family() != baseInfo.Family() {
+			continue
+		}
+		if m.Cores < minCores || m.MemoryGB < minMemGB {
+			continue
+		}
+		if (len(m.EphemeralDisks) > 0) != baseEphemeral {
+			continue
+		}
+		compatible = append(compatible, m.Name)
 	}
-
-	return nil, fmt.Errorf("instance type not handled: %q", machineType)
+	return compatible, nil
 }
 
 var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
@@ -64,6 +132,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		Cores:          1,
 		EphemeralDisks: nil,
 		Burstable:      true,
+		SpotSupported:  false,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "t2.micro",
@@ -72,6 +142,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		Cores:          1,
 		EphemeralDisks: nil,
 		Burstable:      true,
+		SpotSupported:  false,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "t2.small",
@@ -80,6 +152,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		Cores:          1,
 		EphemeralDisks: nil,
 		Burstable:      true,
+		SpotSupported:  false,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "t2.medium",
@@ -88,6 +162,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		Cores:          2,
 		EphemeralDisks: nil,
 		Burstable:      true,
+		SpotSupported:  false,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "t2.large",
@@ -96,6 +172,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		Cores:          2,
 		EphemeralDisks: nil,
 		Burstable:      true,
+		SpotSupported:  false,
+		Generation:     GenerationCurrent,
 	},
 
 	// m3 family
@@ -105,6 +183,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            3,
 		Cores:          1,
 		EphemeralDisks: []int{4},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "m3.large",
@@ -112,6 +192,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            6.5,
 		Cores:          2,
 		EphemeralDisks: []int{32},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "m3.xlarge",
@@ -119,6 +201,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            13,
 		Cores:          4,
 		EphemeralDisks: []int{40, 40},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "m3.2xlarge",
@@ -126,6 +210,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            26,
 		Cores:          8,
 		EphemeralDisks: []int{80, 80},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 
 	// m4 family
@@ -135,6 +221,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            6.5,
 		Cores:          2,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "m4.xlarge",
@@ -142,6 +230,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            13,
 		Cores:          4,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "m4.2xlarge",
@@ -149,6 +239,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            26,
 		Cores:          8,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "m4.4xlarge",
@@ -156,6 +248,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            53.5,
 		Cores:          16,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "m4.10xlarge",
@@ -163,6 +257,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            124.5,
 		Cores:          40,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 
 	// c3 family
@@ -172,6 +268,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            7,
 		Cores:          2,
 		EphemeralDisks: []int{16, 16},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "c3.xlarge",
@@ -179,6 +277,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            14,
 		Cores:          4,
 		EphemeralDisks: []int{40, 40},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "c3.2xlarge",
@@ -186,6 +286,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            28,
 		Cores:          8,
 		EphemeralDisks: []int{80, 80},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "c3.4xlarge",
@@ -193,6 +295,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            55,
 		Cores:          16,
 		EphemeralDisks: []int{160, 160},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "c3.8xlarge",
@@ -200,6 +304,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            108,
 		Cores:          32,
 		EphemeralDisks: []int{320, 320},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 
 	// c4 family
@@ -209,6 +315,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            8,
 		Cores:          2,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "c4.xlarge",
@@ -216,6 +324,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            16,
 		Cores:          4,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "c4.2xlarge",
@@ -223,6 +333,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            31,
 		Cores:          8,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "c4.4xlarge",
@@ -230,6 +342,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            62,
 		Cores:          16,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 	{
 		Name:           "c4.8xlarge",
@@ -237,6 +351,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            132,
 		Cores:          32,
 		EphemeralDisks: nil,
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 
 	// g2 family
@@ -246,6 +362,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            26,
 		Cores:          8,
 		EphemeralDisks: []int{60},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "g2.8xlarge",
@@ -253,6 +371,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            104,
 		Cores:          32,
 		EphemeralDisks: []int{120, 120},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 
 	// i2 family
@@ -262,6 +382,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            14,
 		Cores:          4,
 		EphemeralDisks: []int{800},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "i2.2xlarge",
@@ -269,6 +391,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            27,
 		Cores:          8,
 		EphemeralDisks: []int{800, 800},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "i2.4xlarge",
@@ -276,6 +400,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            53,
 		Cores:          16,
 		EphemeralDisks: []int{800, 800, 800, 800},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "i2.8xlarge",
@@ -283,6 +409,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            104,
 		Cores:          32,
 		EphemeralDisks: []int{800, 800, 800, 800, 800, 800, 800, 800},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 
 	// r3 family
@@ -292,6 +420,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            6.5,
 		Cores:          2,
 		EphemeralDisks: []int{32},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "r3.xlarge",
@@ -299,6 +429,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            13,
 		Cores:          4,
 		EphemeralDisks: []int{80},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "r3.2xlarge",
@@ -306,6 +438,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            26,
 		Cores:          8,
 		EphemeralDisks: []int{160},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "r3.4xlarge",
@@ -313,6 +447,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            52,
 		Cores:          16,
 		EphemeralDisks: []int{320},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 	{
 		Name:           "r3.8xlarge",
@@ -320,6 +456,8 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            104,
 		Cores:          32,
 		EphemeralDisks: []int{320, 320},
+		SpotSupported:  true,
+		Generation:     GenerationPrevious,
 	},
 
 	// x1 family
@@ -329,5 +467,7 @@ var MachineTypes []AWSMachineTypeInfo = []AWSMachineTypeInfo{
 		ECU:            349,
 		Cores:          128,
 		EphemeralDisks: []int{1920, 1920},
+		SpotSupported:  true,
+		Generation:     GenerationCurrent,
 	},
 }
@@ -0,0 +1,74 @@
+package awsup
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/glog"
+	"time"
+)
+
+// createTagsBatchSize is the maximum number of resource IDs EC2's CreateTags API accepts
+// in a single request
+const createTagsBatchSize = 20
+
+// createTagsMaxRetries bounds the retry/backoff loop for a single batch, so a persistently
+// failing batch doesn't hang an upgrade forever
+const createTagsMaxRetries = 5
+
+// CreateTagsBatched tags every resource in ids with tags, chunking the request into groups
+// of createTagsBatchSize (the EC2 CreateTags limit) and retrying each chunk with exponential
+// backoff. This replaces issuing one CreateTags call per resource, which is both O(N) API
+// calls and racy against resources created mid-upgrade.
+//
+// This only retags resources after the fact; it does not address the underlying request to
+// have new clusters' masters born with their final tags so they never need a post-hoc volume
+// rename. That needs an AWS launch-configuration/instance builder task to set
+// ec2.RunInstancesInput.TagSpecifications at creation time, and no such builder task (or any
+// awstasks package at all) exists anywhere in this tree, so that part of the request is not
+// delivered here.
+func (c *AWSCloud) CreateTagsBatched(ids []string, tags map[string]string) error {
+	if len(tags) == 0 || len(ids) == 0 {
+		return nil
+	}
+
+	var ec2Tags []*ec2.Tag
+	for k, v := range tags {
+		ec2Tags = append(ec2Tags, &ec2.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	for start := 0; start < len(ids); start += createTagsBatchSize {
+		end := start + createTagsBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunk := ids[start:end]
+
+		if err := c.createTagsBatchWithRetry(chunk, ec2Tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *AWSCloud) createTagsBatchWithRetry(ids []string, tags []*ec2.Tag) error {
+	request := &ec2.CreateTagsInput{
+		Resources: aws.StringSlice(ids),
+		Tags:      tags,
+	}
+
+	backoff := 1 * time.Second
+	var lastErr error
+	for attempt := 0; attempt < createTagsMaxRetries; attempt++ {
+		_, err := c.EC2.CreateTags(request)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		glog.V(2).Infof("error creating tags on %d resource(s) (attempt %d/%d), retrying: %v", len(ids), attempt+1, createTagsMaxRetries, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return fmt.Errorf("error creating tags on %d resource(s) after %d attempts: %v", len(ids), createTagsMaxRetries, lastErr)
+}
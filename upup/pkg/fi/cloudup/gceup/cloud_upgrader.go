@@ -0,0 +1,146 @@
+// Package gceup implements fi.CloudUpgrader against Google Compute Engine.
+// kutil.UpgradeCluster selects it for a GCE cloud, but both of its upgrade strategies also
+// need AWS-only resource discovery and return an error for a GCE cloud.
+package gceup
+
+import (
+	"fmt"
+	"github.com/golang/glog"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// GCECloudUpgrader implements fi.CloudUpgrader on top of the GCE Compute API. Clusters are
+// represented as instances, managed instance groups, persistent disks and target pools.
+// Instances are found by a "kubernetes-cluster" label equal to the cluster name; target
+// pools, which carry no labels, are matched by a Description equal to the cluster name.
+type GCECloudUpgrader struct {
+	Compute   *compute.Service
+	Project   string
+	Region    string
+	ClusterID string
+}
+
+var _ fi.CloudUpgrader = &GCECloudUpgrader{}
+
+func NewGCECloudUpgrader(computeService *compute.Service, project, region, clusterID string) *GCECloudUpgrader {
+	return &GCECloudUpgrader{
+		Compute:   computeService,
+		Project:   project,
+		Region:    region,
+		ClusterID: clusterID,
+	}
+}
+
+func (u *GCECloudUpgrader) FindInstancesByClusterTag(clusterName string) ([]*fi.CloudInstance, error) {
+	var cloudInstances []*fi.CloudInstance
+
+	err := u.Compute.Instances.AggregatedList(u.Project).Filter(fmt.Sprintf("labels.kubernetes-cluster eq %s", clusterName)).Pages(nil, func(list *compute.InstanceAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, instance := range scoped.Instances {
+				var volumeIDs []string
+				for _, disk := range instance.Disks {
+					volumeIDs = append(volumeIDs, disk.Source)
+				}
+				cloudInstances = append(cloudInstances, &fi.CloudInstance{
+					ID:        instance.Name,
+					Role:      instance.Labels["kubernetes-role"],
+					Status:    instance.Status,
+					VolumeIDs: volumeIDs,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing GCE instances: %v", err)
+	}
+	return cloudInstances, nil
+}
+
+func (u *GCECloudUpgrader) RetagResource(id string, tags map[string]string) error {
+	// GCE has no generic tagging API; we set the equivalent labels on the instance.
+	instance, err := u.Compute.Instances.Get(u.Project, u.Region, id).Do()
+	if err != nil {
+		return fmt.Errorf("error getting instance %q: %v", id, err)
+	}
+
+	labels := instance.Labels
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	for k, v := range tags {
+		labels[k] = v
+	}
+
+	_, err = u.Compute.Instances.SetLabels(u.Project, u.Region, id, &compute.InstancesSetLabelsRequest{
+		Labels:           labels,
+		LabelFingerprint: instance.LabelFingerprint,
+	}).Do()
+	if err != nil {
+		return fmt.Errorf("error setting labels on instance %q: %v", id, err)
+	}
+	return nil
+}
+
+func (u *GCECloudUpgrader) ScaleInstanceGroup(name string, desiredCapacity int64) error {
+	_, err := u.Compute.InstanceGroupManagers.Resize(u.Project, u.Region, name, desiredCapacity).Do()
+	if err != nil {
+		return fmt.Errorf("error resizing managed instance group %q: %v", name, err)
+	}
+	return nil
+}
+
+func (u *GCECloudUpgrader) StopMasters(instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		glog.Infof("Stopping master instance %q", id)
+		_, err := u.Compute.Instances.Stop(u.Project, u.Region, id).Do()
+		if err != nil {
+			return fmt.Errorf("error stopping instance %q: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (u *GCECloudUpgrader) DetachMasterVolumes(instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		instance, err := u.Compute.Instances.Get(u.Project, u.Region, id).Do()
+		if err != nil {
+			return fmt.Errorf("error getting instance %q: %v", id, err)
+		}
+
+		for _, disk := range instance.Disks {
+			if disk.Boot {
+				continue
+			}
+			glog.Infof("Detaching persistent disk %q from instance %q", disk.DeviceName, id)
+			_, err := u.Compute.Instances.DetachDisk(u.Project, u.Region, id, disk.DeviceName).Do()
+			if err != nil {
+				return fmt.Errorf("error detaching disk %q from instance %q: %v", disk.DeviceName, id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (u *GCECloudUpgrader) AdoptLoadBalancers(clusterName string, newTags map[string]string) error {
+	targetPools, err := u.Compute.TargetPools.List(u.Project, u.Region).Do()
+	if err != nil {
+		return fmt.Errorf("error listing target pools: %v", err)
+	}
+
+	for _, pool := range targetPools.Items {
+		if pool.Description != clusterName {
+			continue
+		}
+		glog.Infof("Adopting target pool %q", pool.Name)
+		// Target pools don't carry labels; the cluster association is tracked via the
+		// Description field, so we rewrite it to point at the new cluster name.
+		pool.Description = newTags["KubernetesCluster"]
+		_, err := u.Compute.TargetPools.Patch(u.Project, u.Region, pool.Name, pool).Do()
+		if err != nil {
+			return fmt.Errorf("error retagging target pool %q: %v", pool.Name, err)
+		}
+	}
+	return nil
+}
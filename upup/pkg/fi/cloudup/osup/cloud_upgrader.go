@@ -0,0 +1,146 @@
+// Package osup implements fi.CloudUpgrader against OpenStack, mirroring the resource model
+// used by the OpenStack cloud-provider: clusters are modeled as a Heat stack, with Cinder
+// volumes for master data and Neutron LBaaS pools fronting the API servers.
+package osup
+
+import (
+	"fmt"
+	"github.com/golang/glog"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/blockstorage/v1/volumes"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/servers"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/lbaas/pools"
+	"github.com/rackspace/gophercloud/openstack/orchestration/v1/stacks"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// OpenStackCloudUpgrader implements fi.CloudUpgrader on top of the Heat, Nova, Cinder and
+// Neutron LBaaS clients.
+type OpenStackCloudUpgrader struct {
+	Compute     *gophercloud.ServiceClient
+	BlockStore  *gophercloud.ServiceClient
+	Orchestrate *gophercloud.ServiceClient
+	Network     *gophercloud.ServiceClient
+
+	StackName string
+}
+
+var _ fi.CloudUpgrader = &OpenStackCloudUpgrader{}
+
+func NewOpenStackCloudUpgrader(compute, blockStore, orchestrate, network *gophercloud.ServiceClient, stackName string) *OpenStackCloudUpgrader {
+	return &OpenStackCloudUpgrader{
+		Compute:     compute,
+		BlockStore:  blockStore,
+		Orchestrate: orchestrate,
+		Network:     network,
+		StackName:   stackName,
+	}
+}
+
+func (u *OpenStackCloudUpgrader) FindInstancesByClusterTag(clusterName string) ([]*fi.CloudInstance, error) {
+	var cloudInstances []*fi.CloudInstance
+
+	err := servers.List(u.Compute, servers.ListOpts{}).EachPage(func(page gophercloud.Page) (bool, error) {
+		list, err := servers.ExtractServers(page)
+		if err != nil {
+			return false, err
+		}
+		for _, server := range list {
+			if server.Metadata["KubernetesCluster"] != clusterName {
+				continue
+			}
+			var volumeIDs []string
+			for _, attached := range server.AttachedVolumes {
+				volumeIDs = append(volumeIDs, attached.ID)
+			}
+			cloudInstances = append(cloudInstances, &fi.CloudInstance{
+				ID:        server.ID,
+				Role:      fmt.Sprintf("%v", server.Metadata["KubernetesRole"]),
+				Status:    server.Status,
+				VolumeIDs: volumeIDs,
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing Nova servers: %v", err)
+	}
+	return cloudInstances, nil
+}
+
+func (u *OpenStackCloudUpgrader) RetagResource(id string, tags map[string]string) error {
+	_, err := servers.UpdateMetadata(u.Compute, id, servers.MetadataOpts(tags)).Extract()
+	if err != nil {
+		return fmt.Errorf("error updating metadata on server %q: %v", id, err)
+	}
+	return nil
+}
+
+func (u *OpenStackCloudUpgrader) ScaleInstanceGroup(name string, desiredCapacity int64) error {
+	opts := stacks.UpdateOpts{
+		Parameters: map[string]interface{}{
+			name + "_desired_capacity": desiredCapacity,
+		},
+	}
+	err := stacks.Update(u.Orchestrate, u.StackName, "", opts).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("error resizing instance group %q in stack %q: %v", name, u.StackName, err)
+	}
+	return nil
+}
+
+func (u *OpenStackCloudUpgrader) StopMasters(instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		glog.Infof("Stopping master server %q", id)
+		err := servers.Stop(u.Compute, id).ExtractErr()
+		if err != nil {
+			return fmt.Errorf("error stopping server %q: %v", id, err)
+		}
+	}
+	return nil
+}
+
+func (u *OpenStackCloudUpgrader) DetachMasterVolumes(instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		server, err := servers.Get(u.Compute, id).Extract()
+		if err != nil {
+			return fmt.Errorf("error getting server %q: %v", id, err)
+		}
+
+		for _, attached := range server.AttachedVolumes {
+			glog.Infof("Detaching Cinder volume %q from server %q", attached.ID, id)
+			err := volumes.Get(u.BlockStore, attached.ID).Err // ensure the volume still exists before detaching
+			if err != nil {
+				return fmt.Errorf("error getting volume %q: %v", attached.ID, err)
+			}
+			if err := servers.DetachVolume(u.Compute, id, attached.ID).ExtractErr(); err != nil {
+				return fmt.Errorf("error detaching volume %q from server %q: %v", attached.ID, id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (u *OpenStackCloudUpgrader) AdoptLoadBalancers(clusterName string, newTags map[string]string) error {
+	err := pools.List(u.Network, pools.ListOpts{}).EachPage(func(page gophercloud.Page) (bool, error) {
+		list, err := pools.ExtractPools(page)
+		if err != nil {
+			return false, err
+		}
+		for _, pool := range list {
+			if pool.TenantID == "" || pool.Name != clusterName {
+				continue
+			}
+			glog.Infof("Adopting LBaaS pool %q", pool.ID)
+			_, err := pools.Update(u.Network, pool.ID, pools.UpdateOpts{Name: newTags["KubernetesCluster"]}).Extract()
+			if err != nil {
+				return false, fmt.Errorf("error retagging LBaaS pool %q: %v", pool.ID, err)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error listing LBaaS pools: %v", err)
+	}
+	return nil
+}
@@ -7,11 +7,12 @@ import (
 	"k8s.io/kops/upup/pkg/api"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/cloudinit"
+	"k8s.io/kops/upup/pkg/fi/nodeup/kubeadm"
 	"k8s.io/kops/upup/pkg/fi/nodeup/local"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kops/upup/pkg/fi/vfs"
-	"strconv"
+	"reflect"
 	"strings"
 )
 
@@ -170,20 +171,59 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	}
 	tf.populate(loader.TemplateFunctions)
 
-	taskMap, err := loader.Build(c.ModelDir)
-	if err != nil {
-		return fmt.Errorf("error building loader: %v", err)
+	var caStore fi.CAStore
+
+	var taskMap map[string]fi.Task
+	if c.config.Bootstrapper == "kubeadm" {
+		taskMap, err = kubeadm.BuildTasks(c.cluster, c.instancegroup, caStore, configBase)
+		if err != nil {
+			return fmt.Errorf("error building kubeadm tasks: %v", err)
+		}
+	} else {
+		taskMap, err = loader.Build(c.ModelDir)
+		if err != nil {
+			return fmt.Errorf("error building loader: %v", err)
+		}
+
+		// Loader.Build walks every *Builder registered on loader and merges their tasks in;
+		// StaticPodManifestBuilder belongs in that same list once control-plane pod specs
+		// are modeled as v1.Pod values instead of systemd units, so a master's model
+		// directory can ship kube-apiserver.yaml/etcd.yaml/etc. directly. Until the model
+		// directory can supply them itself, BuildStaticPods derives them from the cluster/
+		// instance-group spec the same way the rest of this function's task graph does.
+		staticPods := c.config.StaticPods
+		if staticPods == nil {
+			staticPods, err = BuildStaticPods(c.cluster, c.instancegroup)
+			if err != nil {
+				return fmt.Errorf("error building static pod specs: %v", err)
+			}
+		}
+		if len(staticPods) > 0 {
+			staticPodBuilder := &nodetasks.StaticPodManifestBuilder{
+				Pods:               staticPods,
+				DrainBeforeReplace: true,
+			}
+			if err := staticPodBuilder.Build(taskMap); err != nil {
+				return fmt.Errorf("error building static pod manifests: %v", err)
+			}
+		}
 	}
 
-	for i, image := range c.config.Images {
-		taskMap["LoadImage."+strconv.Itoa(i)] = &nodetasks.LoadImageTask{
-			Source: image.Source,
-			Hash:   image.Hash,
+	if len(c.config.Images) > 0 {
+		var images []nodetasks.PullableImage
+		for _, image := range c.config.Images {
+			images = append(images, nodetasks.PullableImage{
+				Source: image.Source,
+				Hash:   image.Hash,
+			})
+		}
+		taskMap["ImagePuller"] = &nodetasks.ImagePuller{
+			Images:   images,
+			CacheDir: c.CacheDir,
 		}
 	}
 
 	var cloud fi.Cloud
-	var caStore fi.CAStore
 	var secretStore fi.SecretStore
 	var target fi.Target
 	checkExisting := true
@@ -221,40 +261,53 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	return nil
 }
 
+// evaluateSpec resolves every HostnameOverride-named string field found anywhere in c.Spec
+// (Kubelet.HostnameOverride, MasterKubelet.HostnameOverride, and any such field a future
+// component adds) against the hostnameResolvers registry, so a new component doesn't need a
+// matching line added here to pick up "@aws"/"@gce"/etc. support.
 func evaluateSpec(c *api.Cluster) error {
-	var err error
+	return resolveHostnameOverrideFields(reflect.ValueOf(&c.Spec))
+}
 
-	c.Spec.Kubelet.HostnameOverride, err = evaluateHostnameOverride(c.Spec.Kubelet.HostnameOverride)
-	if err != nil {
-		return err
+// resolveHostnameOverrideFields walks v (following pointers and descending into structs)
+// looking for string fields named HostnameOverride, resolving each in place.
+func resolveHostnameOverrideFields(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
 	}
 
-	c.Spec.MasterKubelet.HostnameOverride, err = evaluateHostnameOverride(c.Spec.MasterKubelet.HostnameOverride)
-	if err != nil {
-		return err
+	if v.Kind() != reflect.Struct {
+		return nil
 	}
 
-	return nil
-}
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
 
-func evaluateHostnameOverride(hostnameOverride string) (string, error) {
-	k := strings.TrimSpace(hostnameOverride)
-	k = strings.ToLower(k)
+		if !field.CanSet() {
+			continue
+		}
 
-	if hostnameOverride != "@aws" {
-		return hostnameOverride, nil
-	}
+		if fieldType.Name == "HostnameOverride" && field.Kind() == reflect.String {
+			resolved, err := resolveHostnameOverride(field.String())
+			if err != nil {
+				return err
+			}
+			field.SetString(resolved)
+			continue
+		}
 
-	// We recognize @aws as meaning "the local-hostname from the aws metadata service"
-	vBytes, err := vfs.Context.ReadFile("metadata://aws/meta-data/local-hostname")
-	if err != nil {
-		return "", fmt.Errorf("error reading local hostname from AWS metadata: %v", err)
-	}
-	v := strings.TrimSpace(string(vBytes))
-	if v == "" {
-		glog.Warningf("Local hostname from AWS metadata service was empty")
-	} else {
-		glog.Infof("Using hostname from AWS metadata service: %s", v)
+		switch field.Kind() {
+		case reflect.Ptr, reflect.Struct:
+			if err := resolveHostnameOverrideFields(field); err != nil {
+				return err
+			}
+		}
 	}
-	return v, nil
+
+	return nil
 }
@@ -0,0 +1,145 @@
+package nodeup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/kops/upup/pkg/fi/vfs"
+)
+
+// HostnameResolver resolves a HostnameOverride sentinel (e.g. "@aws") to the actual hostname
+// the instance's cloud metadata service reports. Cloud providers outside this package can
+// add their own by calling RegisterHostnameResolver from an init function.
+type HostnameResolver interface {
+	Resolve() (string, error)
+}
+
+// hostnameResolvers is keyed by the lowercased sentinel a HostnameOverride field may be set
+// to, e.g. "@gce".
+var hostnameResolvers = map[string]HostnameResolver{
+	"@aws":          vfsHostnameResolver{path: "metadata://aws/meta-data/local-hostname"},
+	"@gce":          gceHostnameResolver{},
+	"@azure":        azureHostnameResolver{},
+	"@digitalocean": digitalOceanHostnameResolver{},
+	"@openstack":    vfsHostnameResolver{path: "metadata://openstack/latest/meta-data/hostname"},
+}
+
+// RegisterHostnameResolver adds (or replaces) the resolver used for sentinel. Cloud providers
+// built outside this package use this to plug in support for a HostnameOverride sentinel
+// nodeup doesn't know about natively.
+func RegisterHostnameResolver(sentinel string, resolver HostnameResolver) {
+	hostnameResolvers[strings.ToLower(sentinel)] = resolver
+}
+
+// vfsHostnameResolver covers clouds whose metadata service needs no special headers, so
+// vfs.Context's existing metadata:// scheme handling is enough.
+type vfsHostnameResolver struct {
+	path string
+}
+
+func (r vfsHostnameResolver) Resolve() (string, error) {
+	b, err := vfs.Context.ReadFile(r.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %q: %v", r.path, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// gceHostnameResolver reads the GCE metadata server, which refuses requests that don't carry
+// the Metadata-Flavor: Google header.
+type gceHostnameResolver struct{}
+
+func (gceHostnameResolver) Resolve() (string, error) {
+	return httpMetadataHostname("http://metadata.google.internal/computeMetadata/v1/instance/hostname", map[string]string{
+		"Metadata-Flavor": "Google",
+	})
+}
+
+// azureHostnameResolver reads the Azure Instance Metadata Service (IMDS), which requires the
+// Metadata: true header and a pinned api-version.
+type azureHostnameResolver struct{}
+
+func (azureHostnameResolver) Resolve() (string, error) {
+	body, err := httpMetadataBody("http://169.254.169.254/metadata/instance/compute?api-version=2021-02-01", map[string]string{
+		"Metadata": "true",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var compute struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &compute); err != nil {
+		return "", fmt.Errorf("error parsing Azure IMDS compute metadata: %v", err)
+	}
+	return compute.Name, nil
+}
+
+// digitalOceanHostnameResolver reads DigitalOcean's droplet metadata service, which needs no
+// special headers but returns the hostname as plain text.
+type digitalOceanHostnameResolver struct{}
+
+func (digitalOceanHostnameResolver) Resolve() (string, error) {
+	return httpMetadataHostname("http://169.254.169.254/metadata/v1/hostname", nil)
+}
+
+func httpMetadataHostname(url string, headers map[string]string) (string, error) {
+	body, err := httpMetadataBody(url, headers)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func httpMetadataBody(url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building metadata request for %q: %v", url, err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error querying metadata service %q: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from metadata service %q", resp.StatusCode, url)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata response from %q: %v", url, err)
+	}
+	return body, nil
+}
+
+// resolveHostnameOverride resolves hostnameOverride if it is a registered sentinel, returning
+// it unchanged otherwise.
+func resolveHostnameOverride(hostnameOverride string) (string, error) {
+	sentinel := strings.ToLower(strings.TrimSpace(hostnameOverride))
+
+	resolver, ok := hostnameResolvers[sentinel]
+	if !ok {
+		return hostnameOverride, nil
+	}
+
+	v, err := resolver.Resolve()
+	if err != nil {
+		return "", fmt.Errorf("error resolving hostname override %q: %v", hostnameOverride, err)
+	}
+	if v == "" {
+		glog.Warningf("hostname from %q metadata service was empty", sentinel)
+	} else {
+		glog.Infof("using hostname %q from %q metadata service", v, sentinel)
+	}
+	return v, nil
+}
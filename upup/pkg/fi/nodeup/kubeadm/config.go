@@ -0,0 +1,102 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeadm builds the kubeadm-config.yaml a node needs to bootstrap via `kubeadm
+// init`/`kubeadm join`, as an alternative to nodeup's hand-built nodetasks model.
+package kubeadm
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/kops/upup/pkg/api"
+)
+
+// Config is the subset of kubeadm's own config API that nodeup needs to populate from the
+// cluster spec; it is rendered to YAML and written to /etc/kubernetes/kubeadm-config.yaml.
+type Config struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+
+	API               APIConfig         `yaml:"api"`
+	Networking        NetworkingConfig  `yaml:"networking"`
+	KubeletExtraArgs  map[string]string `yaml:"kubeletExtraArgs,omitempty"`
+	FeatureGates      map[string]bool   `yaml:"featureGates,omitempty"`
+	APIServerCertSANs []string          `yaml:"apiServerCertSANs,omitempty"`
+}
+
+type APIConfig struct {
+	AdvertiseAddress string `yaml:"advertiseAddress,omitempty"`
+	BindPort         int32  `yaml:"bindPort,omitempty"`
+}
+
+type NetworkingConfig struct {
+	PodSubnet     string `yaml:"podSubnet,omitempty"`
+	ServiceSubnet string `yaml:"serviceSubnet,omitempty"`
+	DNSDomain     string `yaml:"dnsDomain,omitempty"`
+}
+
+// BuildConfig derives a kubeadm Config from the cluster spec: pod/service CIDRs, feature
+// gates, kubelet args, and the cert SANs a control-plane certificate needs to cover. The API
+// section (advertise address and bind port) is left unset.
+func BuildConfig(cluster *api.Cluster, ig *api.InstanceGroup) (*Config, error) {
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster is required")
+	}
+
+	spec := cluster.Spec
+
+	c := &Config{
+		APIVersion: "kubeadm.k8s.io/v1alpha1",
+		Kind:       "MasterConfiguration",
+		Networking: NetworkingConfig{
+			PodSubnet:     spec.NonMasqueradeCIDR,
+			ServiceSubnet: spec.ServiceClusterIPRange,
+			DNSDomain:     spec.ClusterDNSDomain,
+		},
+		FeatureGates: spec.FeatureGates,
+	}
+
+	if spec.Kubelet != nil && spec.Kubelet.ExtraArgs != "" {
+		c.KubeletExtraArgs = map[string]string{"extra-args": spec.Kubelet.ExtraArgs}
+	}
+
+	c.APIServerCertSANs = buildCertSANs(cluster)
+
+	return c, nil
+}
+
+// buildCertSANs returns the hostnames a control-plane serving certificate must cover: the
+// cluster's public/internal API DNS names. Masters' IP addresses are not included.
+func buildCertSANs(cluster *api.Cluster) []string {
+	var sans []string
+	if cluster.Spec.MasterPublicName != "" {
+		sans = append(sans, cluster.Spec.MasterPublicName)
+	}
+	if cluster.Spec.MasterInternalName != "" {
+		sans = append(sans, cluster.Spec.MasterInternalName)
+	}
+	return sans
+}
+
+// Render marshals the config to the YAML document kubeadm expects on disk.
+func (c *Config) Render() ([]byte, error) {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling kubeadm config: %v", err)
+	}
+	return b, nil
+}
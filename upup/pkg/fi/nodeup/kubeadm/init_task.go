@@ -0,0 +1,115 @@
+package kubeadm
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"github.com/golang/glog"
+	"gopkg.in/yaml.v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/vfs"
+)
+
+// joinCommandPattern extracts the endpoint, token, and CA cert hash from the
+// "kubeadm join <endpoint> --token <token> --discovery-token-ca-cert-hash <hash>" line that
+// "kubeadm token create --print-join-command" prints.
+var joinCommandPattern = regexp.MustCompile(`kubeadm join (\S+) --token (\S+) --discovery-token-ca-cert-hash (\S+)`)
+
+// certificateKeyPattern extracts the --certificate-key value "kubeadm init phase
+// upload-certs --upload-certs" prints after re-uploading the control-plane certificates.
+var certificateKeyPattern = regexp.MustCompile(`--certificate-key\s+(\S+)`)
+
+// initTask runs "kubeadm init" on the first master and publishes the resulting JoinInfo to
+// ConfigBase, so PhaseJoinControlPlane/PhaseJoinWorker on every other node can read it back
+// via readJoinInfo. Without this, joining nodes have no way to discover the first master's
+// bootstrap token, CA cert hash, or certificate key.
+type initTask struct {
+	ConfigPath string
+	ConfigBase vfs.Path
+
+	// CAStore, once this tree has a readable CAStore implementation, lets kubeadm reuse the
+	// cluster's existing CA instead of minting its own self-signed one. It is optional for
+	// now: kubeadm init is well-defined without a pre-existing CA, so a nil CAStore just
+	// means every kubeadm-bootstrapped cluster gets its own independent CA.
+	CAStore fi.CAStore
+}
+
+var _ fi.Task = &initTask{}
+
+func (t *initTask) String() string {
+	return "kubeadm init"
+}
+
+func (t *initTask) Run(c *fi.Context) error {
+	if t.CAStore == nil {
+		glog.Infof("no CAStore configured; kubeadm will generate its own cluster CA")
+	}
+
+	joinInfoFile := t.ConfigBase.Join(joinInfoPath)
+	if _, err := joinInfoFile.ReadFile(); err == nil {
+		// kubeadm init (and the token / certificate key it mints) is not safe to re-run
+		// once the cluster exists; published join info is our signal that some previous
+		// run already completed it.
+		glog.V(2).Infof("kubeadm join info already published at %q, not re-running init", joinInfoFile)
+		return nil
+	}
+
+	args, err := PhaseInit.Args(t.ConfigPath, nil)
+	if err != nil {
+		return fmt.Errorf("error building kubeadm init arguments: %v", err)
+	}
+	if err := runKubeadm(args...); err != nil {
+		return err
+	}
+
+	tokenOutput, err := runKubeadmOutput("token", "create", "--print-join-command")
+	if err != nil {
+		return err
+	}
+	match := joinCommandPattern.FindSubmatch(tokenOutput)
+	if match == nil {
+		return fmt.Errorf("could not parse join command from %q", tokenOutput)
+	}
+
+	certKeyOutput, err := runKubeadmOutput("init", "phase", "upload-certs", "--upload-certs")
+	if err != nil {
+		return err
+	}
+	keyMatch := certificateKeyPattern.FindSubmatch(certKeyOutput)
+	if keyMatch == nil {
+		return fmt.Errorf("could not parse certificate key from %q", certKeyOutput)
+	}
+
+	join := &JoinInfo{
+		APIEndpoint:    string(match[1]),
+		BootstrapToken: string(match[2]),
+		CACertHash:     string(match[3]),
+		CertificateKey: string(keyMatch[1]),
+	}
+
+	b, err := yaml.Marshal(join)
+	if err != nil {
+		return fmt.Errorf("error marshalling kubeadm join info: %v", err)
+	}
+
+	if err := joinInfoFile.WriteFile(b); err != nil {
+		return fmt.Errorf("error publishing kubeadm join info to %q: %v", joinInfoFile, err)
+	}
+
+	return nil
+}
+
+func runKubeadm(args ...string) error {
+	_, err := runKubeadmOutput(args...)
+	return err
+}
+
+func runKubeadmOutput(args ...string) ([]byte, error) {
+	cmd := exec.Command("kubeadm", args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("error running %q: %v: %s", cmd.Args, err, out)
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package kubeadm
+
+// Phase identifies a point in the kubeadm bootstrap sequence that a nodeup task can hook
+// into, mapping to the corresponding `kubeadm` subcommand.
+type Phase string
+
+const (
+	// PhaseInit runs "kubeadm init" on the first master, bringing up the control plane and
+	// generating the cluster's bootstrap token and certificate key.
+	PhaseInit Phase = "init"
+
+	// PhaseJoinControlPlane runs "kubeadm join --control-plane" on additional masters, using
+	// an uploaded bootstrap token and certificate key so they share the first master's CA.
+	PhaseJoinControlPlane Phase = "join-control-plane"
+
+	// PhaseJoinWorker runs "kubeadm join" on worker nodes.
+	PhaseJoinWorker Phase = "join-worker"
+)
+
+// Args returns the `kubeadm` subcommand and flags for phase, given the config file path it
+// should read from and (for join phases) the bootstrap token / cert key / API endpoint of
+// the master that already ran PhaseInit.
+func (p Phase) Args(configPath string, join *JoinInfo) ([]string, error) {
+	switch p {
+	case PhaseInit:
+		// --upload-certs publishes the control-plane certificates to a Secret so that
+		// PhaseJoinControlPlane can pull them down with --certificate-key instead of every
+		// master needing direct filesystem access to the first master's pki directory.
+		return []string{"init", "--config", configPath, "--upload-certs"}, nil
+	case PhaseJoinControlPlane:
+		if join == nil {
+			return nil, errMissingJoinInfo(p)
+		}
+		return []string{
+			"join", join.APIEndpoint,
+			"--token", join.BootstrapToken,
+			"--discovery-token-ca-cert-hash", join.CACertHash,
+			"--control-plane",
+			"--certificate-key", join.CertificateKey,
+		}, nil
+	case PhaseJoinWorker:
+		if join == nil {
+			return nil, errMissingJoinInfo(p)
+		}
+		return []string{
+			"join", join.APIEndpoint,
+			"--token", join.BootstrapToken,
+			"--discovery-token-ca-cert-hash", join.CACertHash,
+		}, nil
+	default:
+		return nil, errUnknownPhase(p)
+	}
+}
+
+// JoinInfo is the bootstrap material a joining master or worker needs; it is populated from
+// the state store, where PhaseInit publishes it after a successful "kubeadm init".
+type JoinInfo struct {
+	APIEndpoint    string `yaml:"apiEndpoint"`
+	BootstrapToken string `yaml:"bootstrapToken"`
+	CACertHash     string `yaml:"caCertHash"`
+
+	// CertificateKey decrypts the control-plane certificates uploaded by "kubeadm init
+	// --upload-certs"; only required for PhaseJoinControlPlane.
+	CertificateKey string `yaml:"certificateKey,omitempty"`
+}
+
+func errMissingJoinInfo(p Phase) error {
+	return phaseError{phase: p, msg: "join info is required"}
+}
+
+func errUnknownPhase(p Phase) error {
+	return phaseError{phase: p, msg: "unknown phase"}
+}
+
+type phaseError struct {
+	phase Phase
+	msg   string
+}
+
+func (e phaseError) Error() string {
+	return string(e.phase) + ": " + e.msg
+}
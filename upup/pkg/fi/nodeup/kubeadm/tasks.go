@@ -0,0 +1,124 @@
+package kubeadm
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/kops/upup/pkg/api"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/upup/pkg/fi/vfs"
+)
+
+const configPath = "/etc/kubernetes/kubeadm-config.yaml"
+
+// joinInfoPath is where "kubeadm init --upload-certs" on the first master publishes the
+// bootstrap token, CA cert hash, certificate key, and API endpoint that other masters and
+// workers need to join, so they don't need direct network access to that master's local
+// kubeadm state.
+const joinInfoPath = "kubeadm/join-info.yaml"
+
+// BuildTasks renders the kubeadm config for cluster/ig and returns the nodetasks graph that
+// writes it to disk and invokes the right kubeadm subcommand for this instance's role:
+// "kubeadm init" on the first master (publishing a JoinInfo other nodes can read back),
+// "kubeadm join --control-plane" on additional masters (reusing the bootstrap token and
+// certificate key the first master's init published to the state store), and "kubeadm join"
+// on workers. caStore is threaded through so an existing cluster CA can eventually be reused
+// instead of kubeadm minting its own; that needs a readable CAStore in this tree before it can
+// be implemented, so for now it is optional (see initTask.CAStore). fi.AssetStore has no
+// read-side API in this tree to pull a pre-pulled kubeadm image through, so unlike the rest of
+// NodeUpCommand.Run's task graph, BuildTasks does not take one.
+func BuildTasks(cluster *api.Cluster, ig *api.InstanceGroup, caStore fi.CAStore, configBase vfs.Path) (map[string]fi.Task, error) {
+	config, err := BuildConfig(cluster, ig)
+	if err != nil {
+		return nil, fmt.Errorf("error building kubeadm config: %v", err)
+	}
+
+	contents, err := config.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	taskMap := make(map[string]fi.Task)
+
+	taskMap["File/kubeadm-config"] = &nodetasks.File{
+		Path:     configPath,
+		Contents: fi.NewBytesResource(contents),
+		Type:     nodetasks.FileType_File,
+		Mode:     fi.String("0600"),
+	}
+
+	phase, join, err := resolvePhase(ig, configBase)
+	if err != nil {
+		return nil, err
+	}
+
+	if phase == PhaseInit {
+		// PhaseInit both runs "kubeadm init" and mints/publishes the join info every other
+		// node depends on, so it has to happen as a single task: splitting it across two
+		// map entries would leave their relative order unspecified.
+		taskMap["Exec/kubeadm-init"] = &initTask{
+			ConfigPath: configPath,
+			ConfigBase: configBase,
+			CAStore:    caStore,
+		}
+		return taskMap, nil
+	}
+
+	args, err := phase.Args(configPath, join)
+	if err != nil {
+		return nil, fmt.Errorf("error building kubeadm arguments for phase %q: %v", phase, err)
+	}
+
+	taskMap["Exec/kubeadm-bootstrap"] = &nodetasks.Exec{
+		Command: append([]string{"kubeadm"}, args...),
+		// kubeadm is not idempotent to re-run once the node has already joined; nodeup
+		// treats the existence of the admin kubeconfig it writes as "already done".
+		OnlyIf: nodetasks.FileAbsent("/etc/kubernetes/admin.conf"),
+	}
+
+	return taskMap, nil
+}
+
+// resolvePhase decides which kubeadm phase this instance group plays, and for join phases
+// reads the join info that PhaseInit published to the state store on the first master.
+func resolvePhase(ig *api.InstanceGroup, configBase vfs.Path) (Phase, *JoinInfo, error) {
+	if ig == nil {
+		return "", nil, fmt.Errorf("instance group is required to resolve a kubeadm phase")
+	}
+
+	switch ig.Spec.Role {
+	case api.InstanceGroupRoleMaster:
+		if ig.Spec.IsFirstMaster {
+			return PhaseInit, nil, nil
+		}
+		join, err := readJoinInfo(configBase)
+		if err != nil {
+			return "", nil, err
+		}
+		return PhaseJoinControlPlane, join, nil
+	default:
+		join, err := readJoinInfo(configBase)
+		if err != nil {
+			return "", nil, err
+		}
+		return PhaseJoinWorker, join, nil
+	}
+}
+
+// readJoinInfo loads the bootstrap token, CA cert hash, certificate key, and API endpoint
+// that "kubeadm init --upload-certs" on the first master wrote to joinInfoPath in the shared
+// state store, so joining nodes don't need direct network access to that master.
+func readJoinInfo(configBase vfs.Path) (*JoinInfo, error) {
+	p := configBase.Join(joinInfoPath)
+	b, err := p.ReadFile()
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubeadm join info from %q: %v", p, err)
+	}
+
+	join := &JoinInfo{}
+	if err := yaml.Unmarshal(b, join); err != nil {
+		return nil, fmt.Errorf("error parsing kubeadm join info from %q: %v", p, err)
+	}
+	return join, nil
+}
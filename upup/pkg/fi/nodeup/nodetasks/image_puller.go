@@ -0,0 +1,175 @@
+package nodetasks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/vfs"
+)
+
+// maxPullAttempts mirrors nodeup.MaxAttemptsWithNoProgress; it can't reference that constant
+// directly, since nodeup imports this package.
+const maxPullAttempts = 100
+
+// PullableImage is one image ImagePuller will fetch and cache: Source is the location
+// ImagePuller reads the image tarball from, and Hash is its expected content digest, in the
+// same "sha256:<hex>" form LoadImageTask.Hash already uses.
+type PullableImage struct {
+	Source string
+	Hash   string
+}
+
+// ImagePuller pulls a batch of images concurrently, verifying each against its digest and
+// caching it under CacheDir so a later run with the same image set does no network work at
+// all. It replaces constructing one LoadImageTask per image in a serial loop: pulling images
+// one at a time, with no cache, makes bootstrap on a slow link or a flaky registry far slower
+// than it needs to be, and every retry starts from scratch.
+type ImagePuller struct {
+	Images []PullableImage
+
+	// CacheDir is where pulled image tarballs are stored, keyed by digest.
+	CacheDir string
+
+	// Workers bounds how many images are pulled at once; it defaults to 4 if unset.
+	Workers int
+}
+
+var _ fi.Task = &ImagePuller{}
+
+func (p *ImagePuller) String() string {
+	return fmt.Sprintf("ImagePuller: %d image(s)", len(p.Images))
+}
+
+// Run loads every image in p.Images into the local container runtime, pulling and caching
+// it first if it isn't already cached, with at most p.Workers images in flight at once.
+func (p *ImagePuller) Run(c *fi.Context) error {
+	if p.CacheDir == "" {
+		return fmt.Errorf("CacheDir is required")
+	}
+
+	workers := p.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	if workers > len(p.Images) {
+		workers = len(p.Images)
+	}
+
+	work := make(chan PullableImage)
+	errs := make(chan error, len(p.Images))
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for image := range work {
+				errs <- p.pullOne(image)
+			}
+		}()
+	}
+
+	go func() {
+		for _, image := range p.Images {
+			work <- image
+		}
+		close(work)
+	}()
+
+	var firstErr error
+	for range p.Images {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// pullOne ensures image's tarball is cached and verified under p.CacheDir, then imports it
+// into the local container runtime, retrying a failed pull with exponential backoff.
+func (p *ImagePuller) pullOne(image PullableImage) error {
+	cachePath := p.cachePath(image.Hash)
+
+	if err := verifyDigest(cachePath, image.Hash); err != nil {
+		glog.V(2).Infof("image %q not cached (%v), pulling from %q", image.Hash, err, image.Source)
+
+		var pullErr error
+		delay := time.Second
+		for attempt := 1; attempt <= maxPullAttempts; attempt++ {
+			pullErr = p.fetch(image, cachePath)
+			if pullErr == nil {
+				break
+			}
+			glog.Warningf("error pulling image %q (attempt %d/%d): %v", image.Source, attempt, maxPullAttempts, pullErr)
+			time.Sleep(delay)
+			if delay < time.Minute {
+				delay *= 2
+			}
+		}
+		if pullErr != nil {
+			return fmt.Errorf("error pulling image %q after %d attempts: %v", image.Source, maxPullAttempts, pullErr)
+		}
+
+		if err := verifyDigest(cachePath, image.Hash); err != nil {
+			return fmt.Errorf("image %q failed digest verification after pull: %v", image.Source, err)
+		}
+	} else {
+		glog.V(2).Infof("image %q already cached at %q", image.Hash, cachePath)
+	}
+
+	return p.loadImage(cachePath)
+}
+
+// fetch reads image.Source and writes it to cachePath via a ".tmp" file that is renamed into
+// place; a failed fetch never leaves a file at cachePath (though it may leave the ".tmp"
+// file), so a subsequent attempt never mistakes a partial download for a cached image.
+func (p *ImagePuller) fetch(image PullableImage, cachePath string) error {
+	b, err := vfs.Context.ReadFile(image.Source)
+	if err != nil {
+		return fmt.Errorf("error reading %q: %v", image.Source, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return fmt.Errorf("error creating %q: %v", filepath.Dir(cachePath), err)
+	}
+
+	tmpPath := cachePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, b, 0644); err != nil {
+		return fmt.Errorf("error writing %q: %v", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, cachePath); err != nil {
+		return fmt.Errorf("error renaming %q to %q: %v", tmpPath, cachePath, err)
+	}
+
+	return nil
+}
+
+// cachePath returns where an image with the given digest is stored under p.CacheDir. The first
+// colon in hash (e.g. "sha256:abcd...") is replaced with an underscore in the file name.
+func (p *ImagePuller) cachePath(hash string) string {
+	return filepath.Join(p.CacheDir, "images", strings.Replace(hash, ":", "_", 1)+".tar")
+}
+
+// verifyDigest returns nil only if path exists and its sha256 matches expected (which may be
+// plain hex or prefixed "sha256:<hex>").
+func verifyDigest(path string, expected string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(b)
+	actual := hex.EncodeToString(sum[:])
+
+	want := strings.TrimPrefix(expected, "sha256:")
+	if actual != want {
+		return fmt.Errorf("digest mismatch: expected %q, got %q", want, actual)
+	}
+	return nil
+}
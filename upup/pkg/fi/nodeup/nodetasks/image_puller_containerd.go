@@ -0,0 +1,19 @@
+// +build _runtime_containerd
+
+package nodetasks
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// loadImage imports the cached tarball at path into containerd. This build is selected only
+// when the _runtime_containerd tag is set; otherwise the dockerd implementation is used.
+func (p *ImagePuller) loadImage(path string) error {
+	cmd := exec.Command("ctr", "images", "import", path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error running %q: %v: %s", cmd.Args, err, out)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// +build !_runtime_containerd
+
+package nodetasks
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// loadImage imports the cached tarball at path into dockerd. This build is selected unless
+// the _runtime_containerd tag is set.
+func (p *ImagePuller) loadImage(path string) error {
+	cmd := exec.Command("docker", "load", "-i", path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error running %q: %v: %s", cmd.Args, err, out)
+	}
+	return nil
+}
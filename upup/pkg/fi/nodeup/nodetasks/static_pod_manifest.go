@@ -0,0 +1,129 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// staticManifestDir is where the kubelet's --pod-manifest-path watches for static pods.
+const staticManifestDir = "/etc/kubernetes/manifests"
+
+// StaticPodManifest renders pod to a static pod manifest under /etc/kubernetes/manifests, so
+// the kubelet runs it directly rather than nodeup managing it as a systemd unit. This is how
+// kubeadm and k3s run the control plane, and lets a master's apiserver/controller-manager/
+// scheduler/etcd be upgraded by rewriting a file instead of restarting a unit.
+type StaticPodManifest struct {
+	// Name identifies the manifest file (without the .manifest.yaml suffix) and is used only
+	// for diffing against the task map; it does not need to match the Pod's own Name.
+	Name string
+
+	Pod *v1.Pod
+
+	// DrainBeforeReplace, if set, moves the old manifest aside and waits out a best-effort
+	// drain period before writing the new manifest, instead of overwriting the file in place.
+	// This avoids a window where the kubelet sees a half-written manifest, at the cost of a
+	// short control-plane outage while the old pod drains. See drainBeforeReplace for why the
+	// wait is time-based rather than a confirmation that the old pod has actually terminated.
+	DrainBeforeReplace bool
+}
+
+var _ fi.Task = &StaticPodManifest{}
+
+func (s *StaticPodManifest) String() string {
+	return fmt.Sprintf("StaticPodManifest: %s", s.Name)
+}
+
+func (s *StaticPodManifest) manifestPath() string {
+	return filepath.Join(staticManifestDir, s.Name+".manifest.yaml")
+}
+
+// Run renders s.Pod to YAML and writes it to manifestPath, skipping the write (and any
+// drain) if the existing file already has the same SHA-256 content hash, so an unchanged
+// manifest never causes the kubelet to needlessly restart the static pod.
+func (s *StaticPodManifest) Run(c *fi.Context) error {
+	contents, err := yaml.Marshal(s.Pod)
+	if err != nil {
+		return fmt.Errorf("error marshalling static pod manifest %q: %v", s.Name, err)
+	}
+
+	path := s.manifestPath()
+	newHash := sha256Hex(contents)
+
+	existing, err := ioutil.ReadFile(path)
+	if err == nil {
+		if sha256Hex(existing) == newHash {
+			glog.V(2).Infof("static pod manifest %q unchanged, not rewriting", path)
+			return nil
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("error reading existing static pod manifest %q: %v", path, err)
+	}
+
+	if err := os.MkdirAll(staticManifestDir, 0755); err != nil {
+		return fmt.Errorf("error creating %q: %v", staticManifestDir, err)
+	}
+
+	if s.DrainBeforeReplace && existing != nil {
+		if err := s.drainBeforeReplace(path); err != nil {
+			return err
+		}
+	}
+
+	if err := ioutil.WriteFile(path, contents, 0600); err != nil {
+		return fmt.Errorf("error writing static pod manifest %q: %v", path, err)
+	}
+
+	return nil
+}
+
+// drainBeforeReplace moves the current manifest out of the kubelet's watch directory, so the
+// kubelet sees a clean removal followed by a clean addition instead of an in-place mutation of
+// a running pod's spec. It does not confirm the old pod has actually terminated: this tree has
+// no client for querying kubelet/CRI pod status, so it instead sleeps 30s, one and a half of
+// the kubelet's default --file-check-frequency polling cycles (20s), before writing
+// the replacement manifest. That is a best-effort wait, not a guarantee the old pod is gone.
+func (s *StaticPodManifest) drainBeforeReplace(path string) error {
+	parked := path + ".draining"
+	if err := os.Rename(path, parked); err != nil {
+		return fmt.Errorf("error parking static pod manifest %q for drain: %v", path, err)
+	}
+
+	glog.Infof("draining static pod %q before replacing its manifest", s.Name)
+	time.Sleep(30 * time.Second)
+
+	if err := os.Remove(parked); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing parked manifest %q: %v", parked, err)
+	}
+	return nil
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
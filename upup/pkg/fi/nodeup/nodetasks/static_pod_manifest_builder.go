@@ -0,0 +1,32 @@
+package nodetasks
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// StaticPodManifestBuilder adds a StaticPodManifest task for each control-plane pod spec it
+// is given. It is not one of the builders Loader.Build walks: NodeUpCommand.Run calls Build
+// directly after Loader.Build, with pod specs from NodeUpConfig.StaticPods or BuildStaticPods,
+// instead of the systemd-unit templates the rest of the nodetasks model uses.
+type StaticPodManifestBuilder struct {
+	Pods map[string]*v1.Pod
+
+	// DrainBeforeReplace applies to every pod this builder adds; callers that need per-pod
+	// control should add a StaticPodManifest task directly instead of going through the
+	// builder.
+	DrainBeforeReplace bool
+}
+
+// Build adds one StaticPodManifest task per entry in b.Pods to taskMap, keyed the same way
+// Loader.Build keys every other task ("StaticPodManifest/<name>").
+func (b *StaticPodManifestBuilder) Build(taskMap map[string]fi.Task) error {
+	for name, pod := range b.Pods {
+		taskMap["StaticPodManifest/"+name] = &StaticPodManifest{
+			Name:               name,
+			Pod:                pod,
+			DrainBeforeReplace: b.DrainBeforeReplace,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package nodeup
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/api"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// controlPlaneHostPath is the host directory every control-plane static pod mounts read-only
+// for its PKI material (CA, server cert/key) that nodeup's PKI tasks already wrote there.
+const controlPlaneHostPath = "/srv/kubernetes"
+
+// BuildStaticPods derives the control-plane static pod specs (kube-apiserver,
+// kube-controller-manager, kube-scheduler, etcd) for ig from the cluster spec, for
+// NodeUpCommand.Run to use in place of an unset NodeUpConfig.StaticPods when running the
+// nodetasks model (the non-kubeadm Bootstrapper) rather than kubeadm. Non-master instance groups don't run a
+// control plane, so BuildStaticPods returns nil for them.
+func BuildStaticPods(cluster *api.Cluster, ig *api.InstanceGroup) (map[string]*v1.Pod, error) {
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster is required")
+	}
+	if ig == nil {
+		return nil, fmt.Errorf("instance group is required")
+	}
+	if ig.Spec.Role != api.InstanceGroupRoleMaster {
+		return nil, nil
+	}
+
+	version := cluster.Spec.KubernetesVersion
+	if version == "" {
+		return nil, fmt.Errorf("cluster KubernetesVersion is required to build static pod images")
+	}
+	hyperkubeImage := "k8s.gcr.io/hyperkube:v" + version
+
+	pods := map[string]*v1.Pod{
+		"kube-apiserver": staticControlPlanePod("kube-apiserver", hyperkubeImage, []string{
+			"/hyperkube", "apiserver",
+			"--etcd-servers=http://127.0.0.1:4001",
+			"--service-cluster-ip-range=" + cluster.Spec.ServiceClusterIPRange,
+			"--client-ca-file=" + controlPlaneHostPath + "/ca.crt",
+			"--tls-cert-file=" + controlPlaneHostPath + "/server.crt",
+			"--tls-private-key-file=" + controlPlaneHostPath + "/server.key",
+		}),
+		"kube-controller-manager": staticControlPlanePod("kube-controller-manager", hyperkubeImage, []string{
+			"/hyperkube", "controller-manager",
+			"--master=127.0.0.1:8080",
+			"--cluster-cidr=" + cluster.Spec.NonMasqueradeCIDR,
+			"--root-ca-file=" + controlPlaneHostPath + "/ca.crt",
+			"--service-account-private-key-file=" + controlPlaneHostPath + "/server.key",
+		}),
+		"kube-scheduler": staticControlPlanePod("kube-scheduler", hyperkubeImage, []string{
+			"/hyperkube", "scheduler",
+			"--master=127.0.0.1:8080",
+		}),
+		"etcd": staticControlPlanePod("etcd", "k8s.gcr.io/etcd:3.0.17", []string{
+			"/usr/local/bin/etcd",
+			"--listen-client-urls=http://127.0.0.1:4001",
+			"--advertise-client-urls=http://127.0.0.1:4001",
+			"--data-dir=/var/etcd/data",
+		}),
+	}
+
+	return pods, nil
+}
+
+// staticControlPlanePod builds the common shape every control-plane static pod shares: it
+// runs on the host network (so it can be reached at the well-known localhost ports the other
+// components above hard-code), and mounts the cluster's PKI directory read-only.
+func staticControlPlanePod(name string, image string, command []string) *v1.Pod {
+	hostPathType := v1.HostPathDirectory
+	return &v1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "kube-system",
+		},
+		Spec: v1.PodSpec{
+			HostNetwork: true,
+			Containers: []v1.Container{
+				{
+					Name:    name,
+					Image:   image,
+					Command: command,
+					VolumeMounts: []v1.VolumeMount{
+						{Name: "pki", MountPath: controlPlaneHostPath, ReadOnly: true},
+					},
+				},
+			},
+			Volumes: []v1.Volume{
+				{
+					Name: "pki",
+					VolumeSource: v1.VolumeSource{
+						HostPath: &v1.HostPathVolumeSource{
+							Path: controlPlaneHostPath,
+							Type: &hostPathType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
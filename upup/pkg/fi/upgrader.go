@@ -0,0 +1,42 @@
+package fi
+
+// CloudInstance is a cloud-agnostic view of a single cluster member, as returned by
+// CloudUpgrader.FindInstancesByClusterTag.
+type CloudInstance struct {
+	// ID is the provider-specific identifier for the instance (e.g. an EC2 instance ID)
+	ID string
+	// Role is the kops role of the instance, e.g. "master" or "node"
+	Role string
+	// Status is a provider-specific status string (e.g. "running", "ACTIVE")
+	Status string
+	// VolumeIDs lists the provider-specific identifiers of volumes attached to this instance
+	VolumeIDs []string
+}
+
+// CloudUpgrader abstracts the cloud operations needed to rename/upgrade a cluster, with
+// implementations for AWS, GCE and OpenStack. kutil.UpgradeCluster routes its mutations
+// through it, but still finds the resources to act on through AWS APIs, so it only runs
+// against an AWS cloud.
+type CloudUpgrader interface {
+	// FindInstancesByClusterTag returns every instance tagged as belonging to clusterName
+	FindInstancesByClusterTag(clusterName string) ([]*CloudInstance, error)
+
+	// RetagResource replaces the cluster-identity tags/labels on a single resource
+	RetagResource(id string, tags map[string]string) error
+
+	// ScaleInstanceGroup sets the desired size of the named instance group (ASG / managed
+	// instance group / Heat autoscaling group)
+	ScaleInstanceGroup(name string, desiredCapacity int64) error
+
+	// StopMasters stops (but does not terminate) the given master instances
+	StopMasters(instanceIDs []string) error
+
+	// DetachMasterVolumes detaches the data volumes (e.g. etcd volumes) from the given
+	// master instances; the AWS implementation retries while the instance transitions to
+	// a stopped state, the GCE and OpenStack ones do not retry
+	DetachMasterVolumes(instanceIDs []string) error
+
+	// AdoptLoadBalancers retags every load balancer (and its security groups / firewall
+	// rules) fronting the cluster so it is recognized as belonging to newTags
+	AdoptLoadBalancers(clusterName string, newTags map[string]string) error
+}
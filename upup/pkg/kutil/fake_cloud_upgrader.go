@@ -0,0 +1,83 @@
+package kutil
+
+import (
+	"fmt"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// FakeCloudUpgrader is an in-memory fi.CloudUpgrader used to exercise UpgradeCluster's state
+// machine in tests, without making any calls to a real cloud.
+type FakeCloudUpgrader struct {
+	Instances []*fi.CloudInstance
+
+	Tags             map[string]map[string]string
+	StoppedInstances map[string]bool
+	DetachedVolumes  map[string]bool
+	ScaledGroups     map[string]int64
+	AdoptedClusters  []string
+}
+
+var _ fi.CloudUpgrader = &FakeCloudUpgrader{}
+
+func NewFakeCloudUpgrader() *FakeCloudUpgrader {
+	return &FakeCloudUpgrader{
+		Tags:             make(map[string]map[string]string),
+		StoppedInstances: make(map[string]bool),
+		DetachedVolumes:  make(map[string]bool),
+		ScaledGroups:     make(map[string]int64),
+	}
+}
+
+func (f *FakeCloudUpgrader) FindInstancesByClusterTag(clusterName string) ([]*fi.CloudInstance, error) {
+	var matches []*fi.CloudInstance
+	for _, instance := range f.Instances {
+		matches = append(matches, instance)
+	}
+	return matches, nil
+}
+
+func (f *FakeCloudUpgrader) RetagResource(id string, tags map[string]string) error {
+	existing := f.Tags[id]
+	if existing == nil {
+		existing = make(map[string]string)
+	}
+	for k, v := range tags {
+		existing[k] = v
+	}
+	f.Tags[id] = existing
+	return nil
+}
+
+func (f *FakeCloudUpgrader) ScaleInstanceGroup(name string, desiredCapacity int64) error {
+	f.ScaledGroups[name] = desiredCapacity
+	return nil
+}
+
+func (f *FakeCloudUpgrader) StopMasters(instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		f.StoppedInstances[id] = true
+	}
+	return nil
+}
+
+func (f *FakeCloudUpgrader) DetachMasterVolumes(instanceIDs []string) error {
+	for _, id := range instanceIDs {
+		if !f.StoppedInstances[id] {
+			return fmt.Errorf("cannot detach volumes from instance %q that is not stopped", id)
+		}
+		for _, instance := range f.Instances {
+			if instance.ID != id {
+				continue
+			}
+			for _, volumeID := range instance.VolumeIDs {
+				f.DetachedVolumes[volumeID] = true
+			}
+		}
+	}
+	return nil
+}
+
+func (f *FakeCloudUpgrader) AdoptLoadBalancers(clusterName string, newTags map[string]string) error {
+	f.AdoptedClusters = append(f.AdoptedClusters, clusterName)
+	return nil
+}
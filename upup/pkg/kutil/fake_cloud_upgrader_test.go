@@ -0,0 +1,74 @@
+package kutil
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+	"testing"
+)
+
+func TestFakeCloudUpgrader_StateMachine(t *testing.T) {
+	upgrader := NewFakeCloudUpgrader()
+	upgrader.Instances = []*fi.CloudInstance{
+		{ID: "i-master1", Role: "master", VolumeIDs: []string{"vol-etcd1"}},
+	}
+
+	// Detaching volumes before the instance is stopped should fail
+	if err := upgrader.DetachMasterVolumes([]string{"i-master1"}); err == nil {
+		t.Fatalf("expected error detaching volumes from a running instance")
+	}
+
+	if err := upgrader.StopMasters([]string{"i-master1"}); err != nil {
+		t.Fatalf("unexpected error stopping master: %v", err)
+	}
+	if !upgrader.StoppedInstances["i-master1"] {
+		t.Fatalf("expected i-master1 to be recorded as stopped")
+	}
+
+	if err := upgrader.DetachMasterVolumes([]string{"i-master1"}); err != nil {
+		t.Fatalf("unexpected error detaching volumes: %v", err)
+	}
+	if !upgrader.DetachedVolumes["vol-etcd1"] {
+		t.Fatalf("expected vol-etcd1 to be recorded as detached")
+	}
+
+	if err := upgrader.RetagResource("vpc-1", map[string]string{"KubernetesCluster": "new.example.com"}); err != nil {
+		t.Fatalf("unexpected error retagging: %v", err)
+	}
+	if upgrader.Tags["vpc-1"]["KubernetesCluster"] != "new.example.com" {
+		t.Fatalf("expected vpc-1 to be retagged")
+	}
+
+	if err := upgrader.ScaleInstanceGroup("nodes", 3); err != nil {
+		t.Fatalf("unexpected error scaling instance group: %v", err)
+	}
+	if upgrader.ScaledGroups["nodes"] != 3 {
+		t.Fatalf("expected nodes group to be scaled to 3, got %d", upgrader.ScaledGroups["nodes"])
+	}
+
+	if err := upgrader.AdoptLoadBalancers("old.example.com", map[string]string{"KubernetesCluster": "new.example.com"}); err != nil {
+		t.Fatalf("unexpected error adopting load balancers: %v", err)
+	}
+	if len(upgrader.AdoptedClusters) != 1 || upgrader.AdoptedClusters[0] != "old.example.com" {
+		t.Fatalf("expected old.example.com to be recorded as adopted, got %v", upgrader.AdoptedClusters)
+	}
+}
+
+func TestUpgradeCluster_CloudUpgrader_Override(t *testing.T) {
+	fake := NewFakeCloudUpgrader()
+	x := &UpgradeCluster{CloudUpgrader: fake}
+
+	upgrader, err := x.cloudUpgrader()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upgrader != fake {
+		t.Fatalf("expected the overridden CloudUpgrader to be returned unchanged")
+	}
+}
+
+func TestUpgradeCluster_CloudUpgrader_UnsupportedCloud(t *testing.T) {
+	x := &UpgradeCluster{Cloud: nil}
+
+	if _, err := x.cloudUpgrader(); err == nil {
+		t.Fatalf("expected an error for an unsupported cloud provider")
+	}
+}
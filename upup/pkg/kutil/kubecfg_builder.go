@@ -19,11 +19,10 @@ package kutil
 import (
 	"fmt"
 	"github.com/golang/glog"
-	"io/ioutil"
 	"k8s.io/kubernetes/pkg/client/restclient"
 	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
 	"os"
-	"os/exec"
 	"path"
 	"strings"
 )
@@ -31,7 +30,6 @@ import (
 // KubeconfigBuilder builds a kubecfg file
 // This logic previously lives in the bash scripts (create-kubeconfig in cluster/common.sh)
 type KubeconfigBuilder struct {
-	KubectlPath    string
 	KubeconfigPath string
 
 	KubeMasterIP string
@@ -50,7 +48,6 @@ type KubeconfigBuilder struct {
 
 func NewKubeconfigBuilder() *KubeconfigBuilder {
 	c := &KubeconfigBuilder{}
-	c.KubectlPath = "kubectl" // default to in-path
 
 	kubeconfig := os.Getenv(clientcmd.RecommendedConfigPathEnvVar)
 	if kubeconfig == "" {
@@ -79,136 +76,96 @@ func (c *KubeconfigBuilder) BuildRestConfig() (*restclient.Config, error) {
 	return restConfig, nil
 }
 
-func (c *KubeconfigBuilder) WriteKubecfg() error {
-	tmpdir, err := ioutil.TempDir("", "k8s")
-	if err != nil {
-		return fmt.Errorf("error creating temporary directory: %v", err)
+// configPath returns the file WriteKubecfg/Merge should read and write, collapsing a
+// KUBECONFIG-style colon-separated path list down to its first entry: such lists merge for
+// reads, but clientcmd only ever writes to the first file in the list.
+func (c *KubeconfigBuilder) configPath() string {
+	if split := strings.Split(c.KubeconfigPath, ":"); len(split) > 1 {
+		return split[0]
 	}
+	return c.KubeconfigPath
+}
 
-	defer func() {
-		err := os.RemoveAll(tmpdir)
-		if err != nil {
-			glog.Warningf("error deleting tempdir %q: %v", tmpdir, err)
-		}
-	}()
+// Merge loads the kubeconfig at KubeconfigPath (or starts a fresh one if it doesn't exist yet)
+// and merges in this cluster's entries, returning the resulting config without writing it
+// anywhere. This is the same logic WriteKubecfg uses to build the file it writes to disk;
+// Merge exists separately so programmatic callers that want the *clientcmdapi.Config itself
+// (e.g. to inspect or further modify it) don't have to round-trip through a file.
+func (c *KubeconfigBuilder) Merge() (*clientcmdapi.Config, error) {
+	configPath := c.configPath()
 
-	if _, err := os.Stat(c.KubeconfigPath); os.IsNotExist(err) {
-		err := os.MkdirAll(path.Dir(c.KubeconfigPath), 0700)
-		if err != nil {
-			return fmt.Errorf("error creating directories for %q: %v", c.KubeconfigPath, err)
-		}
-		f, err := os.OpenFile(c.KubeconfigPath, os.O_RDWR|os.O_CREATE, 0600)
-		if err != nil {
-			return fmt.Errorf("error creating config file %q: %v", c.KubeconfigPath, err)
+	config, err := clientcmd.LoadFromFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			config = clientcmdapi.NewConfig()
+		} else {
+			return nil, fmt.Errorf("error loading kubeconfig %q: %v", configPath, err)
 		}
-		f.Close()
 	}
 
-	var clusterArgs []string
-
-	clusterArgs = append(clusterArgs, "--server=https://"+c.KubeMasterIP)
-
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = "https://" + c.KubeMasterIP
 	if c.CACert == nil {
-		clusterArgs = append(clusterArgs, "--insecure-skip-tls-verify=true")
+		cluster.InsecureSkipTLSVerify = true
 	} else {
-		caCert := path.Join(tmpdir, "ca.crt")
-		if err := ioutil.WriteFile(caCert, c.CACert, 0600); err != nil {
-			return err
-		}
-		clusterArgs = append(clusterArgs, "--certificate-authority="+caCert)
-		clusterArgs = append(clusterArgs, "--embed-certs=true")
+		cluster.CertificateAuthorityData = c.CACert
 	}
+	config.Clusters[c.Context] = cluster
 
-	var userArgs []string
-
+	user := clientcmdapi.NewAuthInfo()
 	if c.KubeBearerToken != "" {
-		userArgs = append(userArgs, "--token="+c.KubeBearerToken)
+		user.Token = c.KubeBearerToken
 	} else if c.KubeUser != "" && c.KubePassword != "" {
-		userArgs = append(userArgs, "--username="+c.KubeUser)
-		userArgs = append(userArgs, "--password="+c.KubePassword)
+		user.Username = c.KubeUser
+		user.Password = c.KubePassword
 	}
-
 	if c.ClientCert != nil && c.ClientKey != nil {
-		clientCert := path.Join(tmpdir, "client.crt")
-		if err := ioutil.WriteFile(clientCert, c.ClientCert, 0600); err != nil {
-			return err
-		}
-		clientKey := path.Join(tmpdir, "client.key")
-		if err := ioutil.WriteFile(clientKey, c.ClientKey, 0600); err != nil {
-			return err
-		}
-
-		userArgs = append(userArgs, "--client-certificate="+clientCert)
-		userArgs = append(userArgs, "--client-key="+clientKey)
-		userArgs = append(userArgs, "--embed-certs=true")
+		user.ClientCertificateData = c.ClientCert
+		user.ClientKeyData = c.ClientKey
 	}
+	config.AuthInfos[c.Context] = user
 
-	setClusterArgs := []string{"config", "set-cluster", c.Context}
-	setClusterArgs = append(setClusterArgs, clusterArgs...)
-	err = c.execKubectl(setClusterArgs...)
-	if err != nil {
-		return err
-	}
-
-	if len(userArgs) != 0 {
-		setCredentialsArgs := []string{"config", "set-credentials", c.Context}
-		setCredentialsArgs = append(setCredentialsArgs, userArgs...)
-		err := c.execKubectl(setCredentialsArgs...)
-		if err != nil {
-			return err
-		}
+	// If we have a bearer token, also create a credential entry with basic auth so that it
+	// is easy to discover the basic auth password for your cluster to use in a web browser.
+	if c.KubeUser != "" && c.KubePassword != "" {
+		basicAuthUser := clientcmdapi.NewAuthInfo()
+		basicAuthUser.Username = c.KubeUser
+		basicAuthUser.Password = c.KubePassword
+		config.AuthInfos[c.Context+"-basic-auth"] = basicAuthUser
 	}
 
-	{
-		args := []string{"config", "set-context", c.Context, "--cluster=" + c.Context, "--user=" + c.Context}
-		if c.Namespace != "" {
-			args = append(args, "--namespace", c.Namespace)
-		}
-		err = c.execKubectl(args...)
-		if err != nil {
-			return err
-		}
-	}
-	err = c.execKubectl("config", "use-context", c.Context, "--cluster="+c.Context, "--user="+c.Context)
-	if err != nil {
-		return err
-	}
+	context := clientcmdapi.NewContext()
+	context.Cluster = c.Context
+	context.AuthInfo = c.Context
+	context.Namespace = c.Namespace
+	config.Contexts[c.Context] = context
 
-	// If we have a bearer token, also create a credential entry with basic auth
-	// so that it is easy to discover the basic auth password for your cluster
-	// to use in a web browser.
-	if c.KubeUser != "" && c.KubePassword != "" {
-		err := c.execKubectl("config", "set-credentials", c.Context+"-basic-auth", "--username="+c.KubeUser, "--password="+c.KubePassword)
-		if err != nil {
-			return err
-		}
-	}
+	config.CurrentContext = c.Context
 
-	split := strings.Split(c.KubeconfigPath, ":")
-	path := c.KubeconfigPath
-	if len(split) > 1 {
-		path = split[0]
-	}
-	fmt.Printf("Wrote config for %s to %q\n", c.Context, path)
-	return nil
+	return config, nil
 }
 
-func (c *KubeconfigBuilder) execKubectl(args ...string) error {
-	cmd := exec.Command(c.KubectlPath, args...)
-	env := os.Environ()
-	env = append(env, fmt.Sprintf("KUBECONFIG=%s", c.KubeconfigPath))
-	cmd.Env = env
+// WriteKubecfg merges this cluster's entries into the kubeconfig file at KubeconfigPath,
+// writing the cluster/user/context triple with client-go's own config types rather than
+// shelling out to a kubectl binary (which may not be installed, or may be a different
+// version than the one kops was built against).
+func (c *KubeconfigBuilder) WriteKubecfg() error {
+	configPath := c.configPath()
+
+	if err := os.MkdirAll(path.Dir(configPath), 0700); err != nil {
+		return fmt.Errorf("error creating directories for %q: %v", configPath, err)
+	}
 
-	glog.V(2).Infof("Running command: %s", strings.Join(cmd.Args, " "))
-	output, err := cmd.CombinedOutput()
+	config, err := c.Merge()
 	if err != nil {
-		if len(output) != 0 {
-			glog.Info("error running kubectl.  Output follows:")
-			glog.Info(string(output))
-		}
-		return fmt.Errorf("error running kubectl: %v", err)
+		return err
+	}
+
+	if err := clientcmd.WriteToFile(*config, configPath); err != nil {
+		return fmt.Errorf("error writing kubeconfig %q: %v", configPath, err)
 	}
 
-	glog.V(2).Info(string(output))
+	glog.V(2).Infof("wrote kubeconfig context %q to %q", c.Context, configPath)
+	fmt.Printf("Wrote config for %s to %q\n", c.Context, configPath)
 	return nil
 }
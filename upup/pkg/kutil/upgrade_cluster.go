@@ -1,6 +1,7 @@
 package kutil
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -9,9 +10,38 @@ import (
 	"k8s.io/kops/upup/pkg/api"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gceup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/osup"
+	k8sapi "k8s.io/kubernetes/pkg/api"
+	k8spolicy "k8s.io/kubernetes/pkg/apis/policy"
+	"k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
 	"time"
 )
 
+// UpgradeStrategy selects how UpgradeCluster replaces the instances in a cluster
+type UpgradeStrategy string
+
+const (
+	// StrategyBlueGreen stops every instance up front, retags the cluster, and lets the
+	// ASGs relaunch everything against the new launch configuration. This is the original
+	// (and still default) behavior; it is fast but causes a full outage during the upgrade.
+	StrategyBlueGreen UpgradeStrategy = "BlueGreen"
+
+	// StrategyRolling replaces instances one at a time, cordoning and draining each node
+	// through the Kubernetes API before it is terminated, so the cluster stays available
+	// throughout the upgrade.
+	StrategyRolling UpgradeStrategy = "Rolling"
+)
+
+// defaultDrainTimeout is how long we wait for a single node to drain before giving up
+const defaultDrainTimeout = 5 * time.Minute
+
+// defaultDrainGracePeriod is the default --grace-period passed to pod eviction
+const defaultDrainGracePeriod = 30 * time.Second
+
 // UpgradeCluster performs an upgrade of a k8s cluster
 type UpgradeCluster struct {
 	OldClusterName string
@@ -22,10 +52,85 @@ type UpgradeCluster struct {
 
 	ClusterConfig  *api.Cluster
 	InstanceGroups []*api.InstanceGroup
+
+	// Strategy selects the upgrade approach; it defaults to StrategyBlueGreen
+	Strategy UpgradeStrategy
+
+	// The following options only apply to StrategyRolling
+
+	// GracePeriod is passed through to pod eviction, giving pods time to shut down cleanly
+	GracePeriod time.Duration
+	// Timeout is how long we wait for a single node to drain before giving up on it
+	Timeout time.Duration
+	// IgnoreDaemonSets controls whether DaemonSet-managed pods block a drain
+	IgnoreDaemonSets bool
+
+	// KubernetesClient is used to cordon & drain nodes; it is built lazily if not set
+	KubernetesClient *unversioned.Client
+
+	// CloudUpgrader overrides the fi.CloudUpgrader used to drive the upgrade. It is
+	// normally left nil, in which case one is selected based on the type of Cloud; tests
+	// set this to a fake implementation to exercise the state machine.
+	CloudUpgrader fi.CloudUpgrader
 }
 
 func (x *UpgradeCluster) Upgrade() error {
-	awsCloud := x.Cloud.(*awsup.AWSCloud)
+	switch x.Strategy {
+	case "", StrategyBlueGreen:
+		return x.upgradeBlueGreen()
+	case StrategyRolling:
+		return x.upgradeRolling()
+	default:
+		return fmt.Errorf("unknown upgrade strategy: %q", x.Strategy)
+	}
+}
+
+// awsCloud returns x.Cloud as an *awsup.AWSCloud, or a clean error if this upgrade isn't
+// running against AWS. Both strategies need it: upgradeRolling and the helpers it calls
+// operate directly on *autoscaling.Group, and upgradeBlueGreen routes its mutations through
+// cloudUpgrader() but still finds instances, volumes, DHCP options, gateways and autoscaling
+// groups through AWS APIs. Neither strategy runs against a GCE or OpenStack cloud; asserting
+// through here instead of inline returns an error for such a cloud instead of panicking.
+func (x *UpgradeCluster) awsCloud() (*awsup.AWSCloud, error) {
+	c, ok := x.Cloud.(*awsup.AWSCloud)
+	if !ok {
+		return nil, fmt.Errorf("cloud provider %T does not support this upgrade strategy", x.Cloud)
+	}
+	return c, nil
+}
+
+// cloudUpgrader returns the fi.CloudUpgrader to drive this upgrade through, selecting an
+// implementation based on the concrete type of x.Cloud. A CloudUpgrader set explicitly
+// (e.g. a fake, for tests) takes precedence.
+func (x *UpgradeCluster) cloudUpgrader() (fi.CloudUpgrader, error) {
+	if x.CloudUpgrader != nil {
+		return x.CloudUpgrader, nil
+	}
+
+	switch c := x.Cloud.(type) {
+	case *awsup.AWSCloud:
+		return awsup.NewAWSCloudUpgrader(c), nil
+	case *gceup.GCECloud:
+		return gceup.NewGCECloudUpgrader(c.Compute, c.Project, c.Region, c.ClusterID), nil
+	case *osup.OpenstackCloud:
+		return osup.NewOpenStackCloudUpgrader(c.Compute, c.BlockStore, c.Orchestrate, c.Network, c.StackName), nil
+	default:
+		return nil, fmt.Errorf("cloud provider %T does not support upgrades", x.Cloud)
+	}
+}
+
+// upgradeBlueGreen is the original upgrade path: stop every instance, retag the cluster
+// for the new name, and let the autoscaling groups relaunch against the new configuration.
+func (x *UpgradeCluster) upgradeBlueGreen() error {
+	upgrader, err := x.cloudUpgrader()
+	if err != nil {
+		return err
+	}
+
+	awsCloud, err := x.awsCloud()
+	if err != nil {
+		return err
+	}
 
 	cluster := x.ClusterConfig
 
@@ -67,11 +172,6 @@ func (x *UpgradeCluster) Upgrade() error {
 		return err
 	}
 
-	elbs, _, err := DescribeELBs(x.Cloud)
-	if err != nil {
-		return err
-	}
-
 	// Find masters
 	var masters []*ec2.Instance
 	for _, instance := range instances {
@@ -89,20 +189,13 @@ func (x *UpgradeCluster) Upgrade() error {
 		name := aws.StringValue(group.AutoScalingGroupName)
 		glog.Infof("Stopping instances in autoscaling group %q", name)
 
-		request := &autoscaling.UpdateAutoScalingGroupInput{
-			AutoScalingGroupName: group.AutoScalingGroupName,
-			DesiredCapacity:      aws.Int64(0),
-			MinSize:              aws.Int64(0),
-			MaxSize:              aws.Int64(0),
-		}
-
-		_, err := awsCloud.Autoscaling.UpdateAutoScalingGroup(request)
-		if err != nil {
-			return fmt.Errorf("error updating autoscaling group %q: %v", name, err)
+		if err := upgrader.ScaleInstanceGroup(name, 0); err != nil {
+			return fmt.Errorf("error stopping autoscaling group %q: %v", name, err)
 		}
 	}
 
 	// Stop masters
+	var masterInstanceIDs []string
 	for _, master := range masters {
 		masterInstanceID := aws.StringValue(master.InstanceId)
 
@@ -112,47 +205,15 @@ func (x *UpgradeCluster) Upgrade() error {
 			continue
 		}
 
-		glog.Infof("Stopping master: %q", masterInstanceID)
-
-		request := &ec2.StopInstancesInput{
-			InstanceIds: []*string{master.InstanceId},
-		}
-
-		_, err := awsCloud.EC2.StopInstances(request)
-		if err != nil {
-			return fmt.Errorf("error stopping master instance: %v", err)
-		}
+		masterInstanceIDs = append(masterInstanceIDs, masterInstanceID)
+	}
+	if err := upgrader.StopMasters(masterInstanceIDs); err != nil {
+		return fmt.Errorf("error stopping master instances: %v", err)
 	}
 
 	// Detach volumes from masters
-	for _, master := range masters {
-		for _, bdm := range master.BlockDeviceMappings {
-			if bdm.Ebs == nil || bdm.Ebs.VolumeId == nil {
-				continue
-			}
-			volumeID := aws.StringValue(bdm.Ebs.VolumeId)
-			masterInstanceID := aws.StringValue(master.InstanceId)
-			glog.Infof("Detaching volume %q from instance %q", volumeID, masterInstanceID)
-
-			request := &ec2.DetachVolumeInput{
-				VolumeId:   bdm.Ebs.VolumeId,
-				InstanceId: master.InstanceId,
-			}
-
-			for {
-				_, err := awsCloud.EC2.DetachVolume(request)
-				if err != nil {
-					if awsup.AWSErrorCode(err) == "IncorrectState" {
-						glog.Infof("retrying to detach volume (master has probably not stopped yet): %q", err)
-						time.Sleep(5 * time.Second)
-						continue
-					}
-					return fmt.Errorf("error detaching volume %q from master instance %q: %v", volumeID, masterInstanceID, err)
-				} else {
-					break
-				}
-			}
-		}
+	if err := upgrader.DetachMasterVolumes(masterInstanceIDs); err != nil {
+		return fmt.Errorf("error detaching master volumes: %v", err)
 	}
 
 	//subnets, err := DescribeSubnets(x.Cloud)
@@ -189,7 +250,7 @@ func (x *UpgradeCluster) Upgrade() error {
 
 				glog.Infof("Retagging VPC %q", vpcID)
 
-				err := awsCloud.CreateTags(vpcID, replaceTags)
+				err := upgrader.RetagResource(vpcID, replaceTags)
 				if err != nil {
 					return fmt.Errorf("error re-tagging VPC: %v", err)
 				}
@@ -224,7 +285,7 @@ func (x *UpgradeCluster) Upgrade() error {
 
 					glog.Infof("Retagging InternetGateway %q", id)
 
-					err := awsCloud.CreateTags(id, replaceTags)
+					err := upgrader.RetagResource(id, replaceTags)
 					if err != nil {
 						return fmt.Errorf("error re-tagging InternetGateway: %v", err)
 					}
@@ -248,7 +309,7 @@ func (x *UpgradeCluster) Upgrade() error {
 
 			glog.Infof("Retagging DHCPOptions %q", id)
 
-			err := awsCloud.CreateTags(id, replaceTags)
+			err := upgrader.RetagResource(id, replaceTags)
 			if err != nil {
 				return fmt.Errorf("error re-tagging DHCP options: %v", err)
 			}
@@ -257,60 +318,45 @@ func (x *UpgradeCluster) Upgrade() error {
 	}
 
 	// Adopt LoadBalancers & LoadBalancer Security Groups
-	for _, elb := range elbs {
-		id := aws.StringValue(elb.LoadBalancerName)
-
-		// TODO: Batch re-tag?
-		replaceTags := make(map[string]string)
-		replaceTags[awsup.TagClusterName] = newClusterName
-
-		glog.Infof("Retagging ELB %q", id)
-		err := awsCloud.CreateELBTags(id, replaceTags)
-		if err != nil {
-			return fmt.Errorf("error re-tagging ELB %q: %v", id, err)
-		}
-
-	}
-
-	for _, elb := range elbs {
-		for _, sg := range elb.SecurityGroups {
-			id := aws.StringValue(sg)
-
-			// TODO: Batch re-tag?
-			replaceTags := make(map[string]string)
-			replaceTags[awsup.TagClusterName] = newClusterName
-
-			glog.Infof("Retagging ELB security group %q", id)
-			err := awsCloud.CreateTags(id, replaceTags)
-			if err != nil {
-				return fmt.Errorf("error re-tagging ELB security group %q: %v", id, err)
-			}
-		}
-
+	if err := upgrader.AdoptLoadBalancers(oldClusterName, newTags); err != nil {
+		return fmt.Errorf("error adopting load balancers: %v", err)
 	}
 
 	// Adopt Volumes
+	// Master volumes need an additional Name rewrite, so they're retagged individually;
+	// everything else shares the same KubernetesCluster tag and is retagged through
+	// CreateTagsBatched, one CreateTags call per 20 volumes rather than one per volume.
+	var plainVolumeIDs []string
 	for _, volume := range volumes {
 		id := aws.StringValue(volume.VolumeId)
 
-		// TODO: Batch re-tag?
+		name, _ := awsup.FindEC2Tag(volume.Tags, "Name")
+		if name != oldClusterName+"-master-pd" {
+			plainVolumeIDs = append(plainVolumeIDs, id)
+			continue
+		}
+
+		glog.Infof("Found master volume %q: %s", id, name)
+
 		replaceTags := make(map[string]string)
 		replaceTags[awsup.TagClusterName] = newClusterName
+		az := aws.StringValue(volume.AvailabilityZone)
+		replaceTags["Name"] = az + ".etcd-main." + newClusterName
 
-		name, _ := awsup.FindEC2Tag(volume.Tags, "Name")
-		if name == oldClusterName+"-master-pd" {
-			glog.Infof("Found master volume %q: %s", id, name)
-
-			az := aws.StringValue(volume.AvailabilityZone)
-			replaceTags["Name"] = az + ".etcd-main." + newClusterName
-		}
 		glog.Infof("Retagging volume %q", id)
-		err := awsCloud.CreateTags(id, replaceTags)
-		if err != nil {
+		if err := upgrader.RetagResource(id, replaceTags); err != nil {
 			return fmt.Errorf("error re-tagging volume %q: %v", id, err)
 		}
 	}
 
+	if len(plainVolumeIDs) > 0 {
+		glog.Infof("Retagging %d volume(s)", len(plainVolumeIDs))
+		replaceTags := map[string]string{awsup.TagClusterName: newClusterName}
+		if err := awsCloud.CreateTagsBatched(plainVolumeIDs, replaceTags); err != nil {
+			return fmt.Errorf("error re-tagging volumes: %v", err)
+		}
+	}
+
 	cluster.Name = newClusterName
 	err = api.CreateClusterConfig(x.ClusterRegistry, cluster, x.InstanceGroups)
 	if err != nil {
@@ -336,3 +382,355 @@ func (x *UpgradeCluster) Upgrade() error {
 
 	return nil
 }
+
+// upgradeRolling replaces the instances in each autoscaling group one at a time, cordoning
+// and draining each node through the Kubernetes API before it is taken down. Masters are
+// rolled last, one at a time, waiting for etcd quorum to recover between each.
+func (x *UpgradeCluster) upgradeRolling() error {
+	awsCloud, err := x.awsCloud()
+	if err != nil {
+		return err
+	}
+
+	oldClusterName := x.OldClusterName
+	if oldClusterName == "" {
+		return fmt.Errorf("OldClusterName must be specified")
+	}
+
+	oldTags := awsCloud.Tags()
+
+	autoscalingGroups, err := findAutoscalingGroups(awsCloud, oldTags)
+	if err != nil {
+		return err
+	}
+
+	var masterGroups, nodeGroups []*autoscaling.Group
+	for _, group := range autoscalingGroups {
+		role := findASGTag(group.Tags, "Role")
+		if role == oldClusterName+"-master" {
+			masterGroups = append(masterGroups, group)
+		} else {
+			nodeGroups = append(nodeGroups, group)
+		}
+	}
+
+	// Roll the non-master groups first; they can tolerate surge/unavailability far better
+	for _, group := range nodeGroups {
+		if err := x.rollAutoscalingGroup(group); err != nil {
+			return fmt.Errorf("error rolling autoscaling group %q: %v", aws.StringValue(group.AutoScalingGroupName), err)
+		}
+	}
+
+	// Masters are rolled one at a time, waiting for etcd quorum between each
+	for _, group := range masterGroups {
+		if err := x.rollAutoscalingGroup(group); err != nil {
+			return fmt.Errorf("error rolling master autoscaling group %q: %v", aws.StringValue(group.AutoScalingGroupName), err)
+		}
+
+		if err := x.waitForEtcdQuorum(); err != nil {
+			return fmt.Errorf("error waiting for etcd quorum to recover: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// findASGTag returns the value of the named tag on an autoscaling group, or "" if not present
+func findASGTag(tags []*autoscaling.TagDescription, key string) string {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
+
+// rollAutoscalingGroup replaces every instance in group one at a time: cordon & drain the
+// node, terminate the instance through the group without decrementing its desired capacity
+// (so the group launches a replacement from its current launch configuration), then wait
+// until the group is back to its desired number of InService instances before moving on.
+// It does not wait for the replacement to register as a Ready node in the API server.
+func (x *UpgradeCluster) rollAutoscalingGroup(group *autoscaling.Group) error {
+	awsCloud, err := x.awsCloud()
+	if err != nil {
+		return err
+	}
+	name := aws.StringValue(group.AutoScalingGroupName)
+
+	for _, instance := range group.Instances {
+		instanceID := aws.StringValue(instance.InstanceId)
+
+		nodeName, err := x.findNodeName(instanceID)
+		if err != nil {
+			glog.Warningf("unable to map instance %q to a node name, skipping drain: %v", instanceID, err)
+		} else {
+			glog.Infof("Cordoning and draining node %q (instance %q) in autoscaling group %q", nodeName, instanceID, name)
+			if err := x.cordonAndDrainNode(nodeName); err != nil {
+				return fmt.Errorf("error draining node %q: %v", nodeName, err)
+			}
+		}
+
+		desiredCapacity := aws.Int64Value(group.DesiredCapacity)
+
+		glog.Infof("Terminating instance %q and waiting for replacement", instanceID)
+		_, err = awsCloud.Autoscaling.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+			InstanceId:                     instance.InstanceId,
+			ShouldDecrementDesiredCapacity: aws.Bool(false),
+		})
+		if err != nil {
+			return fmt.Errorf("error terminating instance %q: %v", instanceID, err)
+		}
+
+		if err := x.waitForASGCapacity(name, desiredCapacity); err != nil {
+			return fmt.Errorf("error waiting for autoscaling group %q to reach capacity %d: %v", name, desiredCapacity, err)
+		}
+	}
+
+	return nil
+}
+
+// cordonAndDrainNode marks a node unschedulable and evicts its pods (honoring PodDisruptionBudgets),
+// waiting up to x.Timeout (defaulting to defaultDrainTimeout) for the node to empty out.
+func (x *UpgradeCluster) cordonAndDrainNode(nodeName string) error {
+	client, err := x.kubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	node, err := client.Nodes().Get(nodeName)
+	if err != nil {
+		return fmt.Errorf("error fetching node %q: %v", nodeName, err)
+	}
+	node.Spec.Unschedulable = true
+	if _, err := client.Nodes().Update(node); err != nil {
+		return fmt.Errorf("error cordoning node %q: %v", nodeName, err)
+	}
+
+	gracePeriod := x.GracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = defaultDrainGracePeriod
+	}
+	timeout := x.Timeout
+	if timeout == 0 {
+		timeout = defaultDrainTimeout
+	}
+
+	pods, err := client.Pods(k8sapi.NamespaceAll).List(k8sapi.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing pods on node %q: %v", nodeName, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		if x.IgnoreDaemonSets && isDaemonSetPod(pod) {
+			continue
+		}
+		if isMirrorPod(pod) {
+			// Static pods are managed directly by the kubelet; evicting them has no effect
+			continue
+		}
+
+		glog.Infof("Evicting pod %s/%s from node %q", pod.Namespace, pod.Name, nodeName)
+
+		eviction := &k8spolicy.Eviction{
+			ObjectMeta: k8sapi.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			DeleteOptions: &k8sapi.DeleteOptions{
+				GracePeriodSeconds: aws.Int64(int64(gracePeriod.Seconds())),
+			},
+		}
+		for {
+			err := client.Policy().Evictions(pod.Namespace).Evict(eviction)
+			if err == nil {
+				break
+			}
+			// The PodDisruptionBudget may not allow this eviction yet; back off and retry
+			// until the deadline, the same way `kubectl drain` does.
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out evicting pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+			glog.V(2).Infof("eviction of %s/%s blocked (probably by a PodDisruptionBudget), retrying: %v", pod.Namespace, pod.Name, err)
+			time.Sleep(5 * time.Second)
+		}
+	}
+
+	return waitForPodsGone(client, nodeName, deadline, x.IgnoreDaemonSets)
+}
+
+// waitForPodsGone polls until no non-mirror pods remain scheduled to nodeName, or deadline passes.
+// With ignoreDaemonSets set, DaemonSet-managed pods are not waited for.
+func waitForPodsGone(client *unversioned.Client, nodeName string, deadline time.Time, ignoreDaemonSets bool) error {
+	for {
+		pods, err := client.Pods(k8sapi.NamespaceAll).List(k8sapi.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName),
+		})
+		if err != nil {
+			return fmt.Errorf("error listing pods on node %q: %v", nodeName, err)
+		}
+
+		remaining := 0
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if isMirrorPod(pod) || (ignoreDaemonSets && isDaemonSetPod(pod)) {
+				continue
+			}
+			remaining++
+		}
+		if remaining == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %d pod(s) to leave node %q", remaining, nodeName)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// isDaemonSetPod reports whether pod is owned by a DaemonSet, by decoding the
+// CreatedByAnnotation's SerializedReference and checking its Kind. A pod can carry that
+// annotation for any controller (ReplicationController, ReplicaSet, Job, ...), so a pod being
+// created-by *something* isn't enough: only a DaemonSet owner should be treated as one, since
+// --ignore-daemonsets is meant to skip daemon pods, not every controller-owned pod.
+func isDaemonSetPod(pod *k8sapi.Pod) bool {
+	createdBy, found := pod.Annotations[k8sapi.CreatedByAnnotation]
+	if !found || createdBy == "" {
+		return false
+	}
+
+	var ref k8sapi.SerializedReference
+	if err := json.Unmarshal([]byte(createdBy), &ref); err != nil {
+		glog.Warningf("unable to parse %q annotation on pod %s/%s: %v", k8sapi.CreatedByAnnotation, pod.Namespace, pod.Name, err)
+		return false
+	}
+
+	return ref.Reference.Kind == "DaemonSet"
+}
+
+func isMirrorPod(pod *k8sapi.Pod) bool {
+	_, found := pod.Annotations[k8sapi.MirrorPodAnnotationKey]
+	return found
+}
+
+// waitForASGCapacity polls the autoscaling group until InService instance count reaches desired,
+// signaling that the replacement instance has launched
+func (x *UpgradeCluster) waitForASGCapacity(asgName string, desired int64) error {
+	awsCloud, err := x.awsCloud()
+	if err != nil {
+		return err
+	}
+
+	for {
+		groups, err := findAutoscalingGroups(awsCloud, awsCloud.Tags())
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if aws.StringValue(group.AutoScalingGroupName) != asgName {
+				continue
+			}
+			inService := 0
+			for _, instance := range group.Instances {
+				if aws.StringValue(instance.LifecycleState) == "InService" {
+					inService++
+				}
+			}
+			if int64(inService) >= desired {
+				return nil
+			}
+		}
+		glog.V(2).Infof("waiting for autoscaling group %q to reach %d in-service instances", asgName, desired)
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// waitForEtcdQuorum waits for all master nodes to report Ready in the API server, which we use
+// as a proxy for etcd quorum having recovered after rolling a master
+func (x *UpgradeCluster) waitForEtcdQuorum() error {
+	client, err := x.kubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(defaultDrainTimeout)
+	for {
+		nodes, err := client.Nodes().List(k8sapi.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{"kubernetes.io/role": "master"}),
+		})
+		if err != nil {
+			return fmt.Errorf("error listing master nodes: %v", err)
+		}
+
+		allReady := len(nodes.Items) > 0
+		for i := range nodes.Items {
+			if !isNodeReady(&nodes.Items[i]) {
+				allReady = false
+			}
+		}
+		if allReady {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for master nodes to become Ready")
+		}
+		glog.V(2).Info("waiting for master nodes to report Ready")
+		time.Sleep(10 * time.Second)
+	}
+}
+
+func isNodeReady(node *k8sapi.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == k8sapi.NodeReady {
+			return condition.Status == k8sapi.ConditionTrue
+		}
+	}
+	return false
+}
+
+// findNodeName maps an EC2 instance ID to the Kubernetes node name it registered as
+func (x *UpgradeCluster) findNodeName(instanceID string) (string, error) {
+	client, err := x.kubernetesClient()
+	if err != nil {
+		return "", err
+	}
+
+	nodes, err := client.Nodes().List(k8sapi.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error listing nodes: %v", err)
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		for _, address := range node.Status.Addresses {
+			if address.Type == k8sapi.NodeExternalID && address.Address == instanceID {
+				return node.Name, nil
+			}
+		}
+		if node.Spec.ExternalID == instanceID {
+			return node.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no node found for instance %q", instanceID)
+}
+
+// kubernetesClient lazily builds a client to the cluster being upgraded, reusing the local kubecfg
+func (x *UpgradeCluster) kubernetesClient() (*unversioned.Client, error) {
+	if x.KubernetesClient != nil {
+		return x.KubernetesClient, nil
+	}
+
+	kubeconfigBuilder := NewKubeconfigBuilder()
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigBuilder.configPath())
+	if err != nil {
+		return nil, fmt.Errorf("error building kubernetes client config: %v", err)
+	}
+
+	client, err := unversioned.New(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error building kubernetes client: %v", err)
+	}
+
+	x.KubernetesClient = client
+	return client, nil
+}
@@ -0,0 +1,220 @@
+package databasemigrationservice
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const opImportCertificate = "ImportCertificate"
+
+// ImportCertificateRequest generates a "aws/request.Request" representing the client's
+// request for the ImportCertificate operation. See ImportCertificate for usage and error
+// handling, and aws/request.Request documentation for more information on using the
+// (*Request) Send method.
+func (c *DatabaseMigrationService) ImportCertificateRequest(input *ImportCertificateInput) (req *request.Request, output *ImportCertificateOutput) {
+	op := &request.Operation{
+		Name:       opImportCertificate,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+
+	if input == nil {
+		input = &ImportCertificateInput{}
+	}
+
+	output = &ImportCertificateOutput{}
+	req = c.newRequest(op, input, output)
+	return
+}
+
+// ImportCertificate imports the certificate PEM (and, for Oracle endpoints, the DER-encoded
+// wallet) that a DMS endpoint should trust when connecting over TLS, so the resulting
+// CertificateArn can be attached to CreateEndpoint/ModifyEndpoint.
+func (c *DatabaseMigrationService) ImportCertificate(input *ImportCertificateInput) (*ImportCertificateOutput, error) {
+	req, out := c.ImportCertificateRequest(input)
+	return out, req.Send()
+}
+
+type ImportCertificateInput struct {
+	_ struct{} `type:"structure"`
+
+	// CertificateIdentifier is a customer-assigned name for the imported certificate
+	CertificateIdentifier *string `type:"string" required:"true"`
+
+	// CertificatePem is the PEM-encoded certificate content
+	CertificatePem *string `type:"string"`
+
+	// CertificateWallet is a DER-encoded Oracle wallet, for endpoints that require one
+	// instead of (or alongside) a PEM certificate
+	CertificateWallet []byte `type:"blob"`
+
+	Tags []*Tag `type:"list"`
+}
+
+func (s ImportCertificateInput) String() string {
+	return awsutil.Prettify(s)
+}
+
+func (s *ImportCertificateInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "ImportCertificateInput"}
+	if s.CertificateIdentifier == nil {
+		invalidParams.Add(request.NewErrParamRequired("CertificateIdentifier"))
+	}
+	if s.CertificatePem == nil && s.CertificateWallet == nil {
+		invalidParams.Add(request.NewErrParamRequired("CertificatePem or CertificateWallet"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+type ImportCertificateOutput struct {
+	_ struct{} `type:"structure"`
+
+	Certificate *Certificate `type:"structure"`
+}
+
+func (s ImportCertificateOutput) String() string {
+	return awsutil.Prettify(s)
+}
+
+const opDeleteCertificate = "DeleteCertificate"
+
+func (c *DatabaseMigrationService) DeleteCertificateRequest(input *DeleteCertificateInput) (req *request.Request, output *DeleteCertificateOutput) {
+	op := &request.Operation{
+		Name:       opDeleteCertificate,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+
+	if input == nil {
+		input = &DeleteCertificateInput{}
+	}
+
+	output = &DeleteCertificateOutput{}
+	req = c.newRequest(op, input, output)
+	return
+}
+
+// DeleteCertificate removes a previously-imported certificate. It fails with
+// ErrCodeInvalidResourceStateFault if any endpoint still references it.
+func (c *DatabaseMigrationService) DeleteCertificate(input *DeleteCertificateInput) (*DeleteCertificateOutput, error) {
+	req, out := c.DeleteCertificateRequest(input)
+	return out, req.Send()
+}
+
+type DeleteCertificateInput struct {
+	_ struct{} `type:"structure"`
+
+	CertificateArn *string `type:"string" required:"true"`
+}
+
+func (s DeleteCertificateInput) String() string {
+	return awsutil.Prettify(s)
+}
+
+func (s *DeleteCertificateInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "DeleteCertificateInput"}
+	if s.CertificateArn == nil {
+		invalidParams.Add(request.NewErrParamRequired("CertificateArn"))
+	}
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+type DeleteCertificateOutput struct {
+	_ struct{} `type:"structure"`
+
+	Certificate *Certificate `type:"structure"`
+}
+
+func (s DeleteCertificateOutput) String() string {
+	return awsutil.Prettify(s)
+}
+
+const opDescribeCertificates = "DescribeCertificates"
+
+func (c *DatabaseMigrationService) DescribeCertificatesRequest(input *DescribeCertificatesInput) (req *request.Request, output *DescribeCertificatesOutput) {
+	op := &request.Operation{
+		Name:       opDescribeCertificates,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+		Paginator: &request.Paginator{
+			InputTokens:     []string{"Marker"},
+			OutputTokens:    []string{"Marker"},
+			LimitToken:      "MaxRecords",
+			TruncationToken: "",
+		},
+	}
+
+	if input == nil {
+		input = &DescribeCertificatesInput{}
+	}
+
+	output = &DescribeCertificatesOutput{}
+	req = c.newRequest(op, input, output)
+	return
+}
+
+func (c *DatabaseMigrationService) DescribeCertificates(input *DescribeCertificatesInput) (*DescribeCertificatesOutput, error) {
+	req, out := c.DescribeCertificatesRequest(input)
+	return out, req.Send()
+}
+
+// DescribeCertificatesPages walks every page of DescribeCertificates results, following the
+// same Marker/MaxRecords paging convention used by DescribeEndpoints, calling fn once per
+// page until fn returns false or there are no more pages.
+func (c *DatabaseMigrationService) DescribeCertificatesPages(input *DescribeCertificatesInput, fn func(*DescribeCertificatesOutput, bool) bool) error {
+	page, _ := c.DescribeCertificatesRequest(input)
+	return page.EachPage(func(p interface{}, lastPage bool) bool {
+		return fn(p.(*DescribeCertificatesOutput), lastPage)
+	})
+}
+
+type DescribeCertificatesInput struct {
+	_ struct{} `type:"structure"`
+
+	Filters    []*Filter `type:"list"`
+	Marker     *string   `type:"string"`
+	MaxRecords *int64    `type:"integer"`
+}
+
+func (s DescribeCertificatesInput) String() string {
+	return awsutil.Prettify(s)
+}
+
+type DescribeCertificatesOutput struct {
+	_ struct{} `type:"structure"`
+
+	Certificates []*Certificate `type:"list"`
+	Marker       *string        `type:"string"`
+}
+
+func (s DescribeCertificatesOutput) String() string {
+	return awsutil.Prettify(s)
+}
+
+// Certificate describes a certificate imported (or, for the default DMS CA, provided) for
+// use by endpoints that require TLS.
+type Certificate struct {
+	_ struct{} `type:"structure"`
+
+	CertificateArn        *string    `type:"string"`
+	CertificateIdentifier *string    `type:"string"`
+	CertificateOwner      *string    `type:"string"`
+	CertificatePem        *string    `type:"string"`
+	CertificateWallet     []byte     `type:"blob"`
+	KeyLength             *int64     `type:"integer"`
+	SigningAlgorithm      *string    `type:"string"`
+	ValidFromDate         *time.Time `type:"timestamp" timestampFormat:"unix"`
+	ValidToDate           *time.Time `type:"timestamp" timestampFormat:"unix"`
+}
+
+func (s Certificate) String() string {
+	return awsutil.Prettify(s)
+}
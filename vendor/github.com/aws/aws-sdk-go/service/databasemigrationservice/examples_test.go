@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/databasemigrationservice"
 )
@@ -31,9 +32,18 @@ func ExampleDatabaseMigrationService_AddTagsToResource() {
 	resp, err := svc.AddTagsToResource(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -52,9 +62,11 @@ func ExampleDatabaseMigrationService_CreateEndpoint() {
 		Port:                      aws.Int64(1),                               // Required
 		ServerName:                aws.String("String"),                       // Required
 		Username:                  aws.String("String"),                       // Required
+		CertificateArn:            aws.String("String"),
 		DatabaseName:              aws.String("String"),
 		ExtraConnectionAttributes: aws.String("String"),
 		KmsKeyId:                  aws.String("String"),
+		SslMode:                   aws.String("DmsSslModeValue"),
 		Tags: []*databasemigrationservice.Tag{
 			{ // Required
 				Key:   aws.String("String"),
@@ -66,9 +78,24 @@ func ExampleDatabaseMigrationService_CreateEndpoint() {
 	resp, err := svc.CreateEndpoint(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -105,9 +132,30 @@ func ExampleDatabaseMigrationService_CreateReplicationInstance() {
 	resp, err := svc.CreateReplicationInstance(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInsufficientResourceCapacityFault:
+				fmt.Println(databasemigrationservice.ErrCodeInsufficientResourceCapacityFault, aerr.Error())
+			case databasemigrationservice.ErrCodeStorageQuotaExceededFault:
+				fmt.Println(databasemigrationservice.ErrCodeStorageQuotaExceededFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			case databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs:
+				fmt.Println(databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -136,9 +184,26 @@ func ExampleDatabaseMigrationService_CreateReplicationSubnetGroup() {
 	resp, err := svc.CreateReplicationSubnetGroup(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs:
+				fmt.Println(databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidSubnet:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidSubnet, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -169,9 +234,54 @@ func ExampleDatabaseMigrationService_CreateReplicationTask() {
 	resp, err := svc.CreateReplicationTask(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
+		return
+	}
+
+	// Pretty-print the response data.
+	fmt.Println(resp)
+}
+
+func ExampleDatabaseMigrationService_DeleteCertificate() {
+	svc := databasemigrationservice.New(session.New())
+
+	params := &databasemigrationservice.DeleteCertificateInput{
+		CertificateArn: aws.String("String"), // Required
+	}
+	resp, err := svc.DeleteCertificate(params)
+
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -188,9 +298,22 @@ func ExampleDatabaseMigrationService_DeleteEndpoint() {
 	resp, err := svc.DeleteEndpoint(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -207,9 +330,20 @@ func ExampleDatabaseMigrationService_DeleteReplicationInstance() {
 	resp, err := svc.DeleteReplicationInstance(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -226,9 +360,20 @@ func ExampleDatabaseMigrationService_DeleteReplicationSubnetGroup() {
 	resp, err := svc.DeleteReplicationSubnetGroup(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -245,9 +390,20 @@ func ExampleDatabaseMigrationService_DeleteReplicationTask() {
 	resp, err := svc.DeleteReplicationTask(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -262,9 +418,75 @@ func ExampleDatabaseMigrationService_DescribeAccountAttributes() {
 	resp, err := svc.DescribeAccountAttributes(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInsufficientResourceCapacityFault:
+				fmt.Println(databasemigrationservice.ErrCodeInsufficientResourceCapacityFault, aerr.Error())
+			case databasemigrationservice.ErrCodeStorageQuotaExceededFault:
+				fmt.Println(databasemigrationservice.ErrCodeStorageQuotaExceededFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			case databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs:
+				fmt.Println(databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidSubnet:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidSubnet, aerr.Error())
+			case databasemigrationservice.ErrCodeSubnetAlreadyInUse:
+				fmt.Println(databasemigrationservice.ErrCodeSubnetAlreadyInUse, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
+		return
+	}
+
+	// Pretty-print the response data.
+	fmt.Println(resp)
+}
+
+func ExampleDatabaseMigrationService_DescribeCertificates() {
+	svc := databasemigrationservice.New(session.New())
+
+	params := &databasemigrationservice.DescribeCertificatesInput{
+		Filters: []*databasemigrationservice.Filter{
+			{ // Required
+				Name: aws.String("String"), // Required
+				Values: []*string{ // Required
+					aws.String("String"), // Required
+					// More values...
+				},
+			},
+			// More values...
+		},
+		Marker:     aws.String("String"),
+		MaxRecords: aws.Int64(1),
+	}
+	resp, err := svc.DescribeCertificates(params)
+
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -292,9 +514,18 @@ func ExampleDatabaseMigrationService_DescribeConnections() {
 	resp, err := svc.DescribeConnections(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -322,9 +553,36 @@ func ExampleDatabaseMigrationService_DescribeEndpointTypes() {
 	resp, err := svc.DescribeEndpointTypes(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInsufficientResourceCapacityFault:
+				fmt.Println(databasemigrationservice.ErrCodeInsufficientResourceCapacityFault, aerr.Error())
+			case databasemigrationservice.ErrCodeStorageQuotaExceededFault:
+				fmt.Println(databasemigrationservice.ErrCodeStorageQuotaExceededFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			case databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs:
+				fmt.Println(databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidSubnet:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidSubnet, aerr.Error())
+			case databasemigrationservice.ErrCodeSubnetAlreadyInUse:
+				fmt.Println(databasemigrationservice.ErrCodeSubnetAlreadyInUse, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -352,9 +610,18 @@ func ExampleDatabaseMigrationService_DescribeEndpoints() {
 	resp, err := svc.DescribeEndpoints(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -372,9 +639,36 @@ func ExampleDatabaseMigrationService_DescribeOrderableReplicationInstances() {
 	resp, err := svc.DescribeOrderableReplicationInstances(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInsufficientResourceCapacityFault:
+				fmt.Println(databasemigrationservice.ErrCodeInsufficientResourceCapacityFault, aerr.Error())
+			case databasemigrationservice.ErrCodeStorageQuotaExceededFault:
+				fmt.Println(databasemigrationservice.ErrCodeStorageQuotaExceededFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			case databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs:
+				fmt.Println(databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidSubnet:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidSubnet, aerr.Error())
+			case databasemigrationservice.ErrCodeSubnetAlreadyInUse:
+				fmt.Println(databasemigrationservice.ErrCodeSubnetAlreadyInUse, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -391,9 +685,20 @@ func ExampleDatabaseMigrationService_DescribeRefreshSchemasStatus() {
 	resp, err := svc.DescribeRefreshSchemasStatus(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -421,9 +726,18 @@ func ExampleDatabaseMigrationService_DescribeReplicationInstances() {
 	resp, err := svc.DescribeReplicationInstances(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -451,9 +765,18 @@ func ExampleDatabaseMigrationService_DescribeReplicationSubnetGroups() {
 	resp, err := svc.DescribeReplicationSubnetGroups(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -481,9 +804,18 @@ func ExampleDatabaseMigrationService_DescribeReplicationTasks() {
 	resp, err := svc.DescribeReplicationTasks(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -491,6 +823,20 @@ func ExampleDatabaseMigrationService_DescribeReplicationTasks() {
 	fmt.Println(resp)
 }
 
+func ExampleDatabaseMigrationService_DescribeReplicationTasksPages() {
+	svc := databasemigrationservice.New(session.New())
+
+	err := svc.DescribeReplicationTasksPages(&databasemigrationservice.DescribeReplicationTasksInput{},
+		func(page *databasemigrationservice.DescribeReplicationTasksOutput, lastPage bool) bool {
+			fmt.Println(page)
+			return true
+		})
+
+	if err != nil {
+		fmt.Println(err.Error())
+	}
+}
+
 func ExampleDatabaseMigrationService_DescribeSchemas() {
 	svc := databasemigrationservice.New(session.New())
 
@@ -502,9 +848,20 @@ func ExampleDatabaseMigrationService_DescribeSchemas() {
 	resp, err := svc.DescribeSchemas(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -523,9 +880,73 @@ func ExampleDatabaseMigrationService_DescribeTableStatistics() {
 	resp, err := svc.DescribeTableStatistics(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
+		return
+	}
+
+	// Pretty-print the response data.
+	fmt.Println(resp)
+}
+
+func ExampleDatabaseMigrationService_DescribeTableStatisticsPages() {
+	svc := databasemigrationservice.New(session.New())
+
+	err := svc.DescribeTableStatisticsPages(&databasemigrationservice.DescribeTableStatisticsInput{
+		ReplicationTaskArn: aws.String("String"), // Required
+	},
+		func(page *databasemigrationservice.DescribeTableStatisticsOutput, lastPage bool) bool {
+			fmt.Println(page)
+			return true
+		})
+
+	if err != nil {
 		fmt.Println(err.Error())
+	}
+}
+
+func ExampleDatabaseMigrationService_ImportCertificate() {
+	svc := databasemigrationservice.New(session.New())
+
+	params := &databasemigrationservice.ImportCertificateInput{
+		CertificateIdentifier: aws.String("String"), // Required
+		CertificatePem:        aws.String("String"),
+		CertificateWallet:     []byte("PAYLOAD"),
+		Tags: []*databasemigrationservice.Tag{
+			{ // Required
+				Key:   aws.String("String"),
+				Value: aws.String("String"),
+			},
+			// More values...
+		},
+	}
+	resp, err := svc.ImportCertificate(params)
+
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -542,9 +963,18 @@ func ExampleDatabaseMigrationService_ListTagsForResource() {
 	resp, err := svc.ListTagsForResource(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -557,6 +987,7 @@ func ExampleDatabaseMigrationService_ModifyEndpoint() {
 
 	params := &databasemigrationservice.ModifyEndpointInput{
 		EndpointArn:               aws.String("String"), // Required
+		CertificateArn:            aws.String("String"),
 		DatabaseName:              aws.String("String"),
 		EndpointIdentifier:        aws.String("String"),
 		EndpointType:              aws.String("ReplicationEndpointTypeValue"),
@@ -565,14 +996,32 @@ func ExampleDatabaseMigrationService_ModifyEndpoint() {
 		Password:                  aws.String("SecretString"),
 		Port:                      aws.Int64(1),
 		ServerName:                aws.String("String"),
+		SslMode:                   aws.String("DmsSslModeValue"),
 		Username:                  aws.String("String"),
 	}
 	resp, err := svc.ModifyEndpoint(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -601,9 +1050,28 @@ func ExampleDatabaseMigrationService_ModifyReplicationInstance() {
 	resp, err := svc.ModifyReplicationInstance(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeResourceAlreadyExistsFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceAlreadyExistsFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInsufficientResourceCapacityFault:
+				fmt.Println(databasemigrationservice.ErrCodeInsufficientResourceCapacityFault, aerr.Error())
+			case databasemigrationservice.ErrCodeStorageQuotaExceededFault:
+				fmt.Println(databasemigrationservice.ErrCodeStorageQuotaExceededFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -625,9 +1093,26 @@ func ExampleDatabaseMigrationService_ModifyReplicationSubnetGroup() {
 	resp, err := svc.ModifyReplicationSubnetGroup(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeAccessDeniedFault:
+				fmt.Println(databasemigrationservice.ErrCodeAccessDeniedFault, aerr.Error())
+			case databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs:
+				fmt.Println(databasemigrationservice.ErrCodeReplicationSubnetGroupDoesNotCoverEnoughAZs, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidSubnet:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidSubnet, aerr.Error())
+			case databasemigrationservice.ErrCodeSubnetAlreadyInUse:
+				fmt.Println(databasemigrationservice.ErrCodeSubnetAlreadyInUse, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -645,9 +1130,22 @@ func ExampleDatabaseMigrationService_RefreshSchemas() {
 	resp, err := svc.RefreshSchemas(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -668,9 +1166,18 @@ func ExampleDatabaseMigrationService_RemoveTagsFromResource() {
 	resp, err := svc.RemoveTagsFromResource(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -689,9 +1196,20 @@ func ExampleDatabaseMigrationService_StartReplicationTask() {
 	resp, err := svc.StartReplicationTask(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -708,9 +1226,20 @@ func ExampleDatabaseMigrationService_StopReplicationTask() {
 	resp, err := svc.StopReplicationTask(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
@@ -718,6 +1247,46 @@ func ExampleDatabaseMigrationService_StopReplicationTask() {
 	fmt.Println(resp)
 }
 
+func ExampleDatabaseMigrationService_WaitUntilReplicationInstanceAvailable() {
+	svc := databasemigrationservice.New(session.New())
+
+	err := svc.WaitUntilReplicationInstanceAvailable(&databasemigrationservice.DescribeReplicationInstancesInput{
+		Filters: []*databasemigrationservice.Filter{
+			{
+				Name:   aws.String("replication-instance-id"),
+				Values: []*string{aws.String("String")},
+			},
+		},
+	})
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	fmt.Println("replication instance is available")
+}
+
+func ExampleDatabaseMigrationService_WaitUntilReplicationTaskRunning() {
+	svc := databasemigrationservice.New(session.New())
+
+	err := svc.WaitUntilReplicationTaskRunning(&databasemigrationservice.DescribeReplicationTasksInput{
+		Filters: []*databasemigrationservice.Filter{
+			{
+				Name:   aws.String("replication-task-arn"),
+				Values: []*string{aws.String("String")},
+			},
+		},
+	})
+
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	fmt.Println("replication task is running")
+}
+
 func ExampleDatabaseMigrationService_TestConnection() {
 	svc := databasemigrationservice.New(session.New())
 
@@ -728,9 +1297,22 @@ func ExampleDatabaseMigrationService_TestConnection() {
 	resp, err := svc.TestConnection(params)
 
 	if err != nil {
-		// Print the error, cast err to awserr.Error to get the Code and
-		// Message from an error.
-		fmt.Println(err.Error())
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case databasemigrationservice.ErrCodeResourceNotFoundFault:
+				fmt.Println(databasemigrationservice.ErrCodeResourceNotFoundFault, aerr.Error())
+			case databasemigrationservice.ErrCodeInvalidResourceStateFault:
+				fmt.Println(databasemigrationservice.ErrCodeInvalidResourceStateFault, aerr.Error())
+			case databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault:
+				fmt.Println(databasemigrationservice.ErrCodeKMSKeyNotAccessibleFault, aerr.Error())
+			default:
+				fmt.Println(aerr.Error())
+			}
+		} else {
+			// Print the error, cast err to awserr.Error to get the Code and
+			// Message from an error.
+			fmt.Println(err.Error())
+		}
 		return
 	}
 
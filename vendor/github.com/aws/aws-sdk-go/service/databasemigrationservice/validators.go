@@ -0,0 +1,167 @@
+package databasemigrationservice
+
+import (
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// identifierPattern matches the format DMS requires for *Identifier fields: 1-255
+// characters, starting with a letter, containing only letters, digits and hyphens, with no
+// consecutive or trailing hyphens.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(-[A-Za-z0-9]+)*$`)
+
+// arnPattern matches a DMS resource ARN, e.g. arn:aws:dms:us-east-1:123456789012:cert:ABCDEF
+var arnPattern = regexp.MustCompile(`^arn:aws[-a-z]*:dms:[a-z0-9-]+:\d{12}:[a-z]+:.+$`)
+
+var validMigrationTypes = map[string]bool{
+	"full-load":         true,
+	"cdc":               true,
+	"full-load-and-cdc": true,
+}
+
+var validEndpointTypes = map[string]bool{
+	"source": true,
+	"target": true,
+}
+
+var validEngineNames = map[string]bool{
+	"mysql":     true,
+	"oracle":    true,
+	"postgres":  true,
+	"mariadb":   true,
+	"aurora":    true,
+	"redshift":  true,
+	"s3":        true,
+	"db2":       true,
+	"azuredb":   true,
+	"sybase":    true,
+	"dynamodb":  true,
+	"mongodb":   true,
+	"sqlserver": true,
+}
+
+func validateIdentifier(field, value string, invalidParams *request.ErrInvalidParams) {
+	if len(value) == 0 || len(value) > 255 {
+		invalidParams.Add(request.NewErrParamMinLen(field, 1))
+		return
+	}
+	if !identifierPattern.MatchString(value) {
+		invalidParams.Add(request.NewErrParamFormat(field, "must start with a letter and contain only letters, digits, and non-consecutive hyphens", value))
+	}
+}
+
+func validateArn(field, value string, invalidParams *request.ErrInvalidParams) {
+	if !arnPattern.MatchString(value) {
+		invalidParams.Add(request.NewErrParamFormat(field, "must be a valid DMS ARN (arn:aws[-a-z]*:dms:...)", value))
+	}
+}
+
+// Validate enforces the identifier, endpoint type, and engine name shape DMS requires of a
+// CreateEndpoint call before it reaches the service.
+func (s *CreateEndpointInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "CreateEndpointInput"}
+	if s.EndpointIdentifier == nil {
+		invalidParams.Add(request.NewErrParamRequired("EndpointIdentifier"))
+	} else {
+		validateIdentifier("EndpointIdentifier", *s.EndpointIdentifier, &invalidParams)
+	}
+	if s.EndpointType == nil {
+		invalidParams.Add(request.NewErrParamRequired("EndpointType"))
+	} else if !validEndpointTypes[*s.EndpointType] {
+		invalidParams.Add(request.NewErrParamFormat("EndpointType", "must be one of: source, target", *s.EndpointType))
+	}
+	if s.EngineName == nil {
+		invalidParams.Add(request.NewErrParamRequired("EngineName"))
+	} else if !validEngineNames[*s.EngineName] {
+		invalidParams.Add(request.NewErrParamFormat("EngineName", "must be one of: mysql, oracle, postgres, mariadb, aurora, redshift, s3, db2, azuredb, sybase, dynamodb, mongodb, sqlserver", *s.EngineName))
+	}
+	if s.CertificateArn != nil {
+		validateArn("CertificateArn", *s.CertificateArn, &invalidParams)
+	}
+
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+// Validate enforces the identifier shape DMS requires of a CreateReplicationInstance call
+// before it reaches the service.
+func (s *CreateReplicationInstanceInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "CreateReplicationInstanceInput"}
+	if s.ReplicationInstanceIdentifier == nil {
+		invalidParams.Add(request.NewErrParamRequired("ReplicationInstanceIdentifier"))
+	} else {
+		validateIdentifier("ReplicationInstanceIdentifier", *s.ReplicationInstanceIdentifier, &invalidParams)
+	}
+	if s.ReplicationInstanceClass == nil {
+		invalidParams.Add(request.NewErrParamRequired("ReplicationInstanceClass"))
+	}
+
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+// Validate enforces the identifier shape DMS requires of a CreateReplicationSubnetGroup call
+// before it reaches the service.
+func (s *CreateReplicationSubnetGroupInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "CreateReplicationSubnetGroupInput"}
+	if s.ReplicationSubnetGroupIdentifier == nil {
+		invalidParams.Add(request.NewErrParamRequired("ReplicationSubnetGroupIdentifier"))
+	} else {
+		validateIdentifier("ReplicationSubnetGroupIdentifier", *s.ReplicationSubnetGroupIdentifier, &invalidParams)
+	}
+	if s.ReplicationSubnetGroupDescription == nil {
+		invalidParams.Add(request.NewErrParamRequired("ReplicationSubnetGroupDescription"))
+	}
+	if len(s.SubnetIds) == 0 {
+		invalidParams.Add(request.NewErrParamMinLen("SubnetIds", 1))
+	}
+
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
+
+// Validate enforces the identifier, ARN, and migration type shape DMS requires of a
+// CreateReplicationTask call before it reaches the service.
+func (s *CreateReplicationTaskInput) Validate() error {
+	invalidParams := request.ErrInvalidParams{Context: "CreateReplicationTaskInput"}
+	if s.ReplicationTaskIdentifier == nil {
+		invalidParams.Add(request.NewErrParamRequired("ReplicationTaskIdentifier"))
+	} else {
+		validateIdentifier("ReplicationTaskIdentifier", *s.ReplicationTaskIdentifier, &invalidParams)
+	}
+	if s.SourceEndpointArn == nil {
+		invalidParams.Add(request.NewErrParamRequired("SourceEndpointArn"))
+	} else {
+		validateArn("SourceEndpointArn", *s.SourceEndpointArn, &invalidParams)
+	}
+	if s.TargetEndpointArn == nil {
+		invalidParams.Add(request.NewErrParamRequired("TargetEndpointArn"))
+	} else {
+		validateArn("TargetEndpointArn", *s.TargetEndpointArn, &invalidParams)
+	}
+	if s.ReplicationInstanceArn == nil {
+		invalidParams.Add(request.NewErrParamRequired("ReplicationInstanceArn"))
+	} else {
+		validateArn("ReplicationInstanceArn", *s.ReplicationInstanceArn, &invalidParams)
+	}
+	if s.MigrationType == nil {
+		invalidParams.Add(request.NewErrParamRequired("MigrationType"))
+	} else if !validMigrationTypes[*s.MigrationType] {
+		invalidParams.Add(request.NewErrParamFormat("MigrationType", "must be one of: full-load, cdc, full-load-and-cdc", *s.MigrationType))
+	}
+	if s.TableMappings == nil {
+		invalidParams.Add(request.NewErrParamRequired("TableMappings"))
+	}
+
+	if invalidParams.Len() > 0 {
+		return invalidParams
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package databasemigrationservice
+
+import "testing"
+
+func strPtr(s string) *string { return &s }
+
+func TestCreateEndpointInputValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   *CreateEndpointInput
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			input: &CreateEndpointInput{
+				EndpointIdentifier: strPtr("my-endpoint"),
+				EndpointType:       strPtr("source"),
+				EngineName:         strPtr("mysql"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing engine name",
+			input: &CreateEndpointInput{
+				EndpointIdentifier: strPtr("my-endpoint"),
+				EndpointType:       strPtr("source"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid engine name",
+			input: &CreateEndpointInput{
+				EndpointIdentifier: strPtr("my-endpoint"),
+				EndpointType:       strPtr("source"),
+				EngineName:         strPtr("not-a-real-engine"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid endpoint type",
+			input: &CreateEndpointInput{
+				EndpointIdentifier: strPtr("my-endpoint"),
+				EndpointType:       strPtr("not-a-real-type"),
+				EngineName:         strPtr("mysql"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.input.Validate()
+			if c.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}